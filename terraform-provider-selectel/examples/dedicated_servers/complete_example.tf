@@ -299,10 +299,10 @@ output "available_resources" {
   value = {
     locations = {
       for location in data.selectel_dedicated_server_locations_v1.available_locations.locations :
-      location.code => {
-        id   = location.id
-        name = location.name
-        city = location.city
+      location.name => {
+        id     = location.id
+        name   = location.name
+        region = location.region
       }
     }
     operating_systems = {
@@ -2,19 +2,23 @@ package selectel
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"log"
 	"os"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/tokens"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/selectel/go-selvpcclient/v4/selvpcclient"
-)
-
-var (
-	cfgSingletone *Config
-	once          sync.Once
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/servers"
 )
 
 // Config contains all available configuration options.
@@ -32,35 +36,134 @@ type Config struct {
 	clientsCache   map[string]*selvpcclient.Client
 
 	// Dedicated servers configuration
-	ServersToken  string
-	serversClient *ServersClient
-	lock          sync.Mutex
+	ServersToken          string
+	ServersUsername       string
+	ServersPassword       string
+	ServersProjectID      string
+	ServersEndpoint       string
+	ServersHTTPProxy      string
+	ServersCACertFile     string
+	ServersInsecure       bool
+	ServersRequestTimeout time.Duration
+	DefaultLabels         map[string]string
+	serversClients        map[string]*servers.ServersClient
+	lock                  sync.Mutex
+
+	// serversKeystoneToken получает токен Keystone для API выделенных
+	// серверов, в тестах подменяется
+	serversKeystoneToken func(ctx context.Context) (*servers.Token, error)
 }
 
+// getConfig создает конфигурацию для одного экземпляра провайдера. У каждого
+// alias свой Config, общего состояния между ними нет.
+// Учетные данные берутся из аргументов провайдера, затем из переменных
+// окружения и только затем из профиля файла учетных данных
 func getConfig(d *schema.ResourceData) (*Config, diag.Diagnostics) {
-	// Отключаем singleton для тестирования - создаем конфиг каждый раз заново
-	cfgSingletone = &Config{
-		Username:   d.Get("username").(string),
-		Password:   d.Get("password").(string),
-		DomainName: d.Get("domain_name").(string),
-		AuthURL:    d.Get("auth_url").(string),
-		AuthRegion: d.Get("auth_region").(string),
+	var sharedCredentialsFile, profileName string
+	if v, ok := d.GetOk("shared_credentials_file"); ok {
+		sharedCredentialsFile = v.(string)
+	}
+	if v, ok := d.GetOk("profile"); ok {
+		profileName = v.(string)
+	}
+
+	profile, err := loadSharedCredentialsProfile(sharedCredentialsFile, profileName)
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
+	if profile == nil {
+		profile = &sharedCredentialsProfile{}
+	}
+
+	withProfile := func(key, profileValue string) string {
+		if v, ok := d.GetOk(key); ok {
+			return v.(string)
+		}
+
+		return profileValue
+	}
+
+	config := &Config{
+		Username:       withProfile("username", profile.Username),
+		Password:       withProfile("password", profile.Password),
+		DomainName:     withProfile("domain_name", profile.DomainName),
+		AuthURL:        withProfile("auth_url", profile.AuthURL),
+		AuthRegion:     withProfile("auth_region", profile.AuthRegion),
+		UserDomainName: withProfile("user_domain_name", profile.UserDomainName),
+		ProjectID:      withProfile("project_id", profile.ProjectID),
+		Region:         withProfile("region", profile.Region),
+		// Dedicated servers token (optional)
+		ServersToken:     withProfile("servers_token", profile.ServersToken),
+		ServersUsername:  withProfile("servers_username", profile.ServersUsername),
+		ServersPassword:  withProfile("servers_password", profile.ServersPassword),
+		ServersProjectID: withProfile("servers_project_id", profile.ServersProjectID),
+	}
+
+	var missing []string
+	for key, value := range map[string]string{
+		"auth_url":    config.AuthURL,
+		"auth_region": config.AuthRegion,
+		"domain_name": config.DomainName,
+		"username":    config.Username,
+		"password":    config.Password,
+	} {
+		if value == "" {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, diag.Errorf("provider arguments %s are not set: set them in the provider block, "+
+			"in environment variables or in a shared credentials profile", strings.Join(missing, ", "))
 	}
-	if v, ok := d.GetOk("user_domain_name"); ok {
-		cfgSingletone.UserDomainName = v.(string)
+
+	if (config.ServersUsername == "") != (config.ServersPassword == "") {
+		return nil, diag.Errorf("servers_username and servers_password must be set together")
+	}
+	if config.ServersProjectID != "" && config.ServersUsername == "" {
+		return nil, diag.Errorf("servers_project_id requires servers_username and servers_password")
+	}
+
+	if v, ok := d.GetOk("default_labels"); ok {
+		config.DefaultLabels = expandServerLabels(v)
+	}
+	if v, ok := d.GetOk("servers_endpoint"); ok {
+		config.ServersEndpoint = v.(string)
 	}
-	if v, ok := d.GetOk("project_id"); ok {
-		cfgSingletone.ProjectID = v.(string)
+	if v, ok := d.GetOk("http_proxy"); ok {
+		config.ServersHTTPProxy = v.(string)
 	}
-	if v, ok := d.GetOk("region"); ok {
-		cfgSingletone.Region = v.(string)
+	if v, ok := d.GetOk("ca_cert_file"); ok {
+		config.ServersCACertFile = v.(string)
 	}
-	// Dedicated servers token (optional)
-	if v, ok := d.GetOk("servers_token"); ok {
-		cfgSingletone.ServersToken = v.(string)
+	if v, ok := d.GetOk("insecure"); ok {
+		config.ServersInsecure = v.(bool)
+	}
+	if v, ok := d.GetOk("request_timeout"); ok {
+		config.ServersRequestTimeout = time.Duration(v.(int)) * time.Second
+	}
+
+	return config, nil
+}
+
+// credentialsCacheKey возвращает ключ кеша клиентов для учетных данных
+// провайдера. Пароль и токены в ключ попадают только в виде хеша
+func (c *Config) credentialsCacheKey(parts ...string) string {
+	hash := sha256.New()
+	for _, part := range append([]string{
+		c.AuthURL, c.AuthRegion, c.DomainName, c.UserDomainName, c.Username, c.Password,
+	}, parts...) {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
 	}
 
-	return cfgSingletone, nil
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// serversClientCacheKey возвращает ключ кеша клиентов API выделенных
+// серверов
+func (c *Config) serversClientCacheKey() string {
+	return c.credentialsCacheKey(c.ServersToken, c.ServersUsername, c.ServersPassword, c.ServersProjectID, c.ServersEndpoint)
 }
 
 func (c *Config) GetSelVPCClient() (*selvpcclient.Client, error) {
@@ -71,7 +174,7 @@ func (c *Config) GetSelVPCClientWithProjectScope(projectID string) (*selvpcclien
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
-	clientsCacheKey := fmt.Sprintf("client_%s", projectID)
+	clientsCacheKey := fmt.Sprintf("client_%s_%s", c.credentialsCacheKey(), projectID)
 
 	if client, ok := c.clientsCache[clientsCacheKey]; ok {
 		return client, nil
@@ -102,63 +205,154 @@ func (c *Config) GetSelVPCClientWithProjectScope(projectID string) (*selvpcclien
 }
 
 // GetServersClient возвращает клиент для работы с выделенными серверами
-func (c *Config) GetServersClient() (*ServersClient, error) {
+func (c *Config) GetServersClient() (*servers.ServersClient, error) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
 	fmt.Fprintf(os.Stderr, "*** GetServersClient() CALLED ***\n")
-	log.Printf("[INFO] GetServersClient called, current ServersToken: %s", c.ServersToken)
+	log.Printf("[INFO] GetServersClient called, servers_token set: %t", c.ServersToken != "")
 
 	// Если клиент уже создан, возвращаем его
-	if c.serversClient != nil {
+	serversClientCacheKey := c.serversClientCacheKey()
+	if client, ok := c.serversClients[serversClientCacheKey]; ok {
 		fmt.Fprintf(os.Stderr, "*** REUSING CACHED SERVERS CLIENT ***\n")
-		return c.serversClient, nil
+		return client, nil
+	}
+
+	// Создаем клиент для выделенных серверов
+	opts := &servers.ServersClientOptions{
+		TokenSource:     c.serversTokenSource(),
+		AuthDescription: c.serversAuthDescription(),
+		BaseURL:         c.ServersEndpoint,
+		ProxyURL:        c.ServersHTTPProxy,
+		CACertFile:      c.ServersCACertFile,
+		Insecure:        c.ServersInsecure,
+		Timeout:         c.ServersRequestTimeout,
+	}
+
+	client, err := servers.NewServersClient(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create servers client: %w", err)
 	}
 
-	var token string
+	if c.serversClients == nil {
+		c.serversClients = map[string]*servers.ServersClient{}
+	}
 
-	// Приоритет: используем servers_token если указан, иначе получаем токен через Keystone
+	c.serversClients[serversClientCacheKey] = client
+
+	return client, nil
+}
+
+// serversTokenSource возвращает источник токенов для API выделенных
+// серверов. Заданный servers_token используется как есть: если API его
+// отклонит, запрос завершится ошибкой, а не продолжится от имени другого
+// пользователя. Без него токен получается через Keystone для сервисного
+// пользователя servers_username или, если он не задан, для пользователя
+// провайдера и обновляется до истечения срока
+func (c *Config) serversTokenSource() servers.TokenSource {
 	if c.ServersToken != "" {
 		log.Printf("[DEBUG] Using provided servers_token (length: %d)", len(c.ServersToken))
-		log.Printf("[DEBUG] Provided token: %s", c.ServersToken)
-		token = c.ServersToken
-	} else {
-		log.Printf("[INFO] No servers_token provided, attempting Keystone authentication")
-		log.Printf("[INFO] ATTEMPTING KEYSTONE AUTH WITH USER: %s", c.Username)
-		fmt.Fprintf(os.Stderr, "*** KEYSTONE AUTH ATTEMPT FOR USER: %s ***\n", c.Username)
-		// Получаем токен через автоматическую аутентификацию Keystone
-		selvpcClient, err := c.GetSelVPCClient()
+		return servers.StaticTokenSource(c.ServersToken)
+	}
+
+	keystoneToken := c.serversKeystoneToken
+	if keystoneToken == nil {
+		keystoneToken = c.newServersKeystoneToken
+	}
+
+	return servers.NewRefreshingTokenSource(func(ctx context.Context) (*servers.Token, error) {
+		log.Printf("[INFO] Obtaining dedicated servers API token via Keystone for %s", c.serversKeystoneIdentity())
+		token, err := keystoneToken(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get selvpc client for servers authentication: %w", err)
+			return nil, fmt.Errorf("failed to obtain authentication token via Keystone: %w", err)
 		}
 
-		keystoneToken := selvpcClient.GetXAuthToken()
-		if keystoneToken == "" {
-			return nil, fmt.Errorf("failed to obtain authentication token via Keystone")
-		}
+		log.Printf("[INFO] Successfully obtained Keystone token (length: %d)", len(token.Value))
+
+		return token, nil
+	}, servers.DefaultTokenRefreshMargin)
+}
 
-		log.Printf("[INFO] Successfully obtained Keystone token (length: %d)", len(keystoneToken))
-		log.Printf("[INFO] Keystone token: %s", keystoneToken)
-		token = keystoneToken
+// serversKeystoneIdentity описывает, для кого и с какой областью действия
+// выпускается токен Keystone для API выделенных серверов
+func (c *Config) serversKeystoneIdentity() string {
+	if c.ServersUsername == "" {
+		return fmt.Sprintf("user %s (domain-scoped token for account %s)", c.Username, c.DomainName)
+	}
+	if c.ServersProjectID == "" {
+		return fmt.Sprintf("service user %s (domain-scoped token for account %s)", c.ServersUsername, c.DomainName)
 	}
 
-	// Создаем клиент для выделенных серверов
-	opts := &ServersClientOptions{
-		Token:   token,
-		Context: c.Context,
+	return fmt.Sprintf("service user %s (project-scoped token for project %s)", c.ServersUsername, c.ServersProjectID)
+}
+
+// serversAuthDescription описывает владельца токенов API выделенных
+// серверов для ошибок авторизации
+func (c *Config) serversAuthDescription() string {
+	if c.ServersToken != "" {
+		return "servers_token (it is not refreshed: replace it or unset it to authenticate via Keystone)"
+	}
+
+	return c.serversKeystoneIdentity()
+}
+
+// newServersKeystoneToken получает новый токен Keystone. Кешированный
+// клиент selvpcclient не подходит: он возвращает последний выданный токен
+// и не сообщает срок его действия
+func (c *Config) newServersKeystoneToken(ctx context.Context) (*servers.Token, error) {
+	authOpts := gophercloud.AuthOptions{
+		IdentityEndpoint: c.AuthURL,
+		Username:         c.Username,
+		Password:         c.Password,
+		DomainName:       c.UserDomainName,
+		Scope:            &gophercloud.AuthScope{},
+	}
+	if authOpts.DomainName == "" {
+		authOpts.DomainName = c.DomainName
+	}
+
+	// Сервисный пользователь IAM создается в домене аккаунта
+	hint := "check username and password"
+	if c.ServersUsername != "" {
+		authOpts.Username = c.ServersUsername
+		authOpts.Password = c.ServersPassword
+		authOpts.DomainName = c.DomainName
+		authOpts.Scope.ProjectID = c.ServersProjectID
+
+		hint = fmt.Sprintf("check that the service user exists in account %s and its password", c.DomainName)
+		if c.ServersProjectID != "" {
+			hint += fmt.Sprintf(", and that it has a role in project %s", c.ServersProjectID)
+		}
+	}
+	if authOpts.Scope.ProjectID == "" {
+		authOpts.Scope.DomainName = c.DomainName
 	}
 
-	client, err := NewServersClient(opts)
+	provider, err := openstack.AuthenticatedClientWithContext(ctx, authOpts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create servers client: %w", err)
+		return nil, fmt.Errorf("failed to obtain a token for %s, %s: %w", c.serversKeystoneIdentity(), hint, err)
 	}
 
-	c.serversClient = client
-	return client, nil
+	// Срок действия берется из ответа Keystone. Если его нет, токен
+	// обновляется только после отказа API
+	result, ok := provider.GetAuthResult().(tokens.CreateResult)
+	if !ok {
+		return nil, fmt.Errorf("keystone returned an unexpected response for %s", c.serversKeystoneIdentity())
+	}
+	token, err := result.ExtractToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the token of %s from the keystone response: %w", c.serversKeystoneIdentity(), err)
+	}
+	if token.ID == "" {
+		return nil, fmt.Errorf("keystone returned an empty token for %s", c.serversKeystoneIdentity())
+	}
+
+	return &servers.Token{Value: token.ID, ExpiresAt: token.ExpiresAt}, nil
 }
 
 // GetServersService возвращает сервис для работы с выделенными серверами
-func (c *Config) GetServersService() (*ServersService, error) {
+func (c *Config) GetServersService() (servers.ServersAPI, error) {
 	log.Printf("[INFO] GetServersService() called")
 	client, err := c.GetServersClient()
 	if err != nil {
@@ -167,5 +361,5 @@ func (c *Config) GetServersService() (*ServersService, error) {
 	}
 
 	log.Printf("[INFO] GetServersService: client created successfully")
-	return NewServersService(client), nil
+	return servers.NewServersService(client), nil
 }
@@ -0,0 +1,95 @@
+package selectel
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceDedicatedOSTemplateV1() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDedicatedOSTemplateV1Read,
+		Schema: map[string]*schema.Schema{
+			"template_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"template_id", "name"},
+				Description:  "UUID of the install template",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Name of the install template",
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"based_on": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"arch": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"install_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"content": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"created_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceDedicatedOSTemplateV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	serversService, err := config.GetServersService()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	templateID := d.Get("template_id").(string)
+	ref := templateID
+	if ref == "" {
+		ref = d.Get("name").(string)
+	}
+
+	log.Print(msgGet(objectServerOSTemplate, ref))
+
+	templates, err := serversService.ListOSTemplates(ctx)
+	if err != nil {
+		return diag.FromErr(errGettingObjects(objectServerOSTemplate, err))
+	}
+
+	find := findServerOSTemplate
+	if templateID != "" {
+		find = findServerOSTemplateByUUID
+	}
+
+	template, err := find(templates, ref)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(template.UUID)
+	d.Set("template_id", template.UUID)
+	setServerOSTemplate(d, template)
+
+	return nil
+}
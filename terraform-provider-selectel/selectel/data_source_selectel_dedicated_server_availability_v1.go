@@ -0,0 +1,168 @@
+package selectel
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/servers"
+)
+
+func dataSourceDedicatedServerAvailabilityV1() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDedicatedServerAvailabilityV1Read,
+		Schema: map[string]*schema.Schema{
+			"service_uuid": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Return stock of this service only",
+			},
+			"location_uuid": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Return stock in this location only",
+			},
+			"config_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Description: "Return stock of this configuration only",
+			},
+			"in_stock_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Skip services that are sold out",
+			},
+			"availability": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"service_uuid": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"service_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"config_id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"location_uuid": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"location_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"quantity": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"in_stock": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"restock_at": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDedicatedServerAvailabilityV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	serversService, err := config.GetServersService()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	listOpts := &servers.ServerAvailabilityListOpts{
+		ServiceUUID:  d.Get("service_uuid").(string),
+		LocationUUID: d.Get("location_uuid").(string),
+	}
+	configID := d.Get("config_id").(int)
+	inStockOnly := d.Get("in_stock_only").(bool)
+
+	log.Printf("[DEBUG] Reading %s list with options: %+v", objectServerAvailability, listOpts)
+
+	availability, err := serversService.ListServerAvailability(ctx, listOpts)
+	if err != nil {
+		return diag.FromErr(errGettingObjects(objectServerAvailability, err))
+	}
+
+	// Имена локаций и услуг нужны, чтобы выбирать локацию в конфигурации
+	// без отдельных data source
+	locations, err := serversService.ListLocations(ctx)
+	if err != nil {
+		return diag.FromErr(errGettingObjects("server locations", err))
+	}
+	locationNames := make(map[string]string, len(locations))
+	for _, location := range locations {
+		locationNames[location.UUID] = location.Name
+	}
+
+	services, err := serversService.GetServices(ctx)
+	if err != nil {
+		return diag.FromErr(errGettingObjects("server services", err))
+	}
+	serviceNames := make(map[string]string, len(services))
+	for _, service := range services {
+		serviceNames[service.UUID] = service.Name
+	}
+
+	filtered := make([]*servers.ServerAvailability, 0, len(availability))
+	for _, av := range availability {
+		if !serverAvailabilityMatchesConfig(av, configID) {
+			continue
+		}
+		if inStockOnly && av.Quantity <= 0 {
+			continue
+		}
+		filtered = append(filtered, av)
+	}
+
+	sort.SliceStable(filtered, func(i, j int) bool {
+		if locationNames[filtered[i].LocationUUID] != locationNames[filtered[j].LocationUUID] {
+			return locationNames[filtered[i].LocationUUID] < locationNames[filtered[j].LocationUUID]
+		}
+		return serviceNames[filtered[i].ServiceUUID] < serviceNames[filtered[j].ServiceUUID]
+	})
+
+	availabilityList := make([]interface{}, len(filtered))
+	for i, av := range filtered {
+		restockAt := ""
+		if av.RestockAt != nil {
+			restockAt = av.RestockAt.Format(time.RFC3339)
+		}
+		availabilityList[i] = map[string]interface{}{
+			"service_uuid":  av.ServiceUUID,
+			"service_name":  serviceNames[av.ServiceUUID],
+			"config_id":     av.ConfigID,
+			"location_uuid": av.LocationUUID,
+			"location_name": locationNames[av.LocationUUID],
+			"quantity":      av.Quantity,
+			"in_stock":      av.Quantity > 0,
+			"restock_at":    restockAt,
+		}
+	}
+
+	if err := d.Set("availability", availabilityList); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("server-availability-%s-%s-%d-%t", listOpts.ServiceUUID, listOpts.LocationUUID, configID, inStockOnly))
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package selectel
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/fakeservers"
+)
+
+func TestAccDataSourceDedicatedServerAvailabilityV1Basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccSelectelDedicatedServersPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceDedicatedServerAvailabilityV1Config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.selectel_dedicated_server_availability_v1.in_stock", "availability.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceDedicatedServerAvailabilityV1Fake(t *testing.T) {
+	api := fakeservers.New()
+	defer api.Close()
+
+	const msk2UUID = "0b1f3a57-9b67-5bd6-a7a1-3f0bd5cc2f73"
+	restockAt := time.Date(2026, time.November, 2, 0, 0, 0, 0, time.UTC)
+
+	api.AddLocation(fakeservers.Location{UUID: msk2UUID, Name: "MSK-2", LocationID: 2})
+	api.SetAvailability(fakeservers.Availability{
+		ServiceUUID:  fakeservers.ServiceUUID,
+		ConfigID:     fakeservers.ConfigurationID,
+		LocationUUID: msk2UUID,
+		RestockAt:    &restockAt,
+	})
+
+	ctx := context.Background()
+	meta := testServersFakeConfig(t, api)
+
+	d := schema.TestResourceDataRaw(t, dataSourceDedicatedServerAvailabilityV1().Schema, map[string]interface{}{
+		"service_uuid": fakeservers.ServiceUUID,
+	})
+	diags := dataSourceDedicatedServerAvailabilityV1Read(ctx, d, meta)
+	require.False(t, diags.HasError(), diags)
+
+	availability := d.Get("availability").([]interface{})
+	require.Len(t, availability, 2)
+	assert.Equal(t, map[string]interface{}{
+		"service_uuid":  fakeservers.ServiceUUID,
+		"service_name":  fakeservers.ServiceName,
+		"config_id":     fakeservers.ConfigurationID,
+		"location_uuid": msk2UUID,
+		"location_name": "MSK-2",
+		"quantity":      0,
+		"in_stock":      false,
+		"restock_at":    "2026-11-02T00:00:00Z",
+	}, availability[0])
+	assert.Equal(t, fakeservers.LocationName, availability[1].(map[string]interface{})["location_name"])
+	assert.Equal(t, fakeservers.DefaultStock, availability[1].(map[string]interface{})["quantity"])
+
+	requests := api.Requests()
+	assert.Equal(t, "service_uuid="+fakeservers.ServiceUUID, requests[0].Query)
+
+	inStock := schema.TestResourceDataRaw(t, dataSourceDedicatedServerAvailabilityV1().Schema, map[string]interface{}{
+		"in_stock_only": true,
+	})
+	diags = dataSourceDedicatedServerAvailabilityV1Read(ctx, inStock, meta)
+	require.False(t, diags.HasError(), diags)
+
+	availability = inStock.Get("availability").([]interface{})
+	require.Len(t, availability, 1)
+	assert.Equal(t, fakeservers.LocationUUID, availability[0].(map[string]interface{})["location_uuid"])
+}
+
+const testAccDataSourceDedicatedServerAvailabilityV1Config = `
+data "selectel_dedicated_server_availability_v1" "in_stock" {
+  in_stock_only = true
+}
+`
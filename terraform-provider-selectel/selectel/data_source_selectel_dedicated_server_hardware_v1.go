@@ -0,0 +1,136 @@
+package selectel
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceDedicatedServerHardwareV1() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDedicatedServerHardwareV1Read,
+		Schema: map[string]*schema.Schema{
+			"server_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID or UUID of the server",
+			},
+			"disks": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"slot": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"serial": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"model": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"size_gb": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"firmware": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+				Description: "Physical disks of the server",
+			},
+			"nics": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"mac_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"model": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"speed_mbps": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"link_state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+				Description: "Network interfaces of the server",
+			},
+			"bios_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "BIOS firmware version",
+			},
+			"bmc_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "BMC (IPMI) firmware version",
+			},
+		},
+	}
+}
+
+func dataSourceDedicatedServerHardwareV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	serversService, err := config.GetServersService()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	serverID := d.Get("server_id").(string)
+
+	log.Print(msgGet(objectServerHardware, serverID))
+
+	hardware, err := serversService.GetServerHardware(ctx, serverID)
+	if err != nil {
+		return diag.FromErr(errGettingObject(objectServerHardware, serverID, err))
+	}
+	if hardware == nil {
+		return diag.FromErr(errReadFromResponse(objectServerHardware))
+	}
+
+	d.SetId(serverID)
+
+	if err := d.Set("disks", flattenServerDisks(hardware.Disks)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("nics", flattenServerNICs(hardware.NICs)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if hardware.Firmware != nil {
+		if err := d.Set("bios_version", hardware.Firmware.BIOSVersion); err != nil {
+			return diag.FromErr(err)
+		}
+
+		if err := d.Set("bmc_version", hardware.Firmware.BMCVersion); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package selectel
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/fakeservers"
+)
+
+func TestAccDataSourceDedicatedServerHardwareV1Basic(t *testing.T) {
+	serverID := testAccSelectelDedicatedServerIDForTests()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccSelectelDedicatedServersPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceDedicatedServerHardwareV1Config(serverID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.selectel_dedicated_server_hardware_v1.hardware_1", "server_id", serverID),
+					resource.TestCheckResourceAttrSet("data.selectel_dedicated_server_hardware_v1.hardware_1", "disks.0.serial"),
+					resource.TestCheckResourceAttrSet("data.selectel_dedicated_server_hardware_v1.hardware_1", "disks.0.slot"),
+					resource.TestCheckResourceAttrSet("data.selectel_dedicated_server_hardware_v1.hardware_1", "nics.0.mac_address"),
+					resource.TestCheckResourceAttrSet("data.selectel_dedicated_server_hardware_v1.hardware_1", "nics.0.speed_mbps"),
+					resource.TestCheckResourceAttrSet("data.selectel_dedicated_server_hardware_v1.hardware_1", "bios_version"),
+					resource.TestCheckResourceAttrSet("data.selectel_dedicated_server_hardware_v1.hardware_1", "bmc_version"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitDataSourceDedicatedServerHardwareV1Basic(t *testing.T) {
+	api := fakeservers.New()
+	defer api.Close()
+
+	server := api.AddServer(fakeservers.Server{Name: "storage-1"})
+	api.SetHardware(server.ID, &fakeservers.Hardware{
+		Disks: []map[string]interface{}{
+			{"slot": 0, "serial": "S4EVNX0N", "model": "SAMSUNG MZ7LH480", "type": "SSD", "size_gb": 480},
+			{"slot": 1, "serial": "S4EVNX1M", "model": "SAMSUNG MZ7LH480", "type": "SSD", "size_gb": 480},
+		},
+		NICs: []map[string]interface{}{
+			{"name": "eno1", "mac_address": "0c:c4:7a:aa:bb:01", "speed_mbps": 10000, "link_state": "up"},
+		},
+		Firmware: map[string]string{"bios_version": "3.4", "bmc_version": "1.73"},
+	})
+	serverID := strconv.Itoa(server.ID)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testServersFakePreCheck(t) },
+		ProviderFactories: testServersFakeProviderFactories(api),
+		Steps: []resource.TestStep{
+			{
+				Config: testServersFakeProviderBlock + testAccDataSourceDedicatedServerHardwareV1Config(serverID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.selectel_dedicated_server_hardware_v1.hardware_1", "disks.#", "2"),
+					resource.TestCheckResourceAttr("data.selectel_dedicated_server_hardware_v1.hardware_1", "disks.1.serial", "S4EVNX1M"),
+					resource.TestCheckResourceAttr("data.selectel_dedicated_server_hardware_v1.hardware_1", "nics.0.mac_address", "0c:c4:7a:aa:bb:01"),
+					resource.TestCheckResourceAttr("data.selectel_dedicated_server_hardware_v1.hardware_1", "nics.0.speed_mbps", "10000"),
+					resource.TestCheckResourceAttr("data.selectel_dedicated_server_hardware_v1.hardware_1", "bmc_version", "1.73"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceDedicatedServerHardwareV1Config(serverID string) string {
+	return fmt.Sprintf(`
+data "selectel_dedicated_server_hardware_v1" "hardware_1" {
+  server_id = "%s"
+}`, serverID)
+}
@@ -2,16 +2,32 @@ package selectel
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/servers"
 )
 
 func dataSourceDedicatedServerLocationsV1() *schema.Resource {
 	return &schema.Resource{
 		ReadContext: dataSourceDedicatedServerLocationsV1Read,
 		Schema: map[string]*schema.Schema{
+			"filter": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Location name, e.g. SPB-4",
+						},
+					},
+				},
+			},
 			"locations": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -29,22 +45,42 @@ func dataSourceDedicatedServerLocationsV1() *schema.Resource {
 							Type:     schema.TypeString,
 							Computed: true,
 						},
-						"code": {
+						"description": {
 							Type:     schema.TypeString,
 							Computed: true,
 						},
-						"country": {
+						"region": {
 							Type:     schema.TypeString,
 							Computed: true,
 						},
-						"city": {
-							Type:     schema.TypeString,
+						"dc_count": {
+							Type:     schema.TypeInt,
 							Computed: true,
 						},
-						"datacenter": {
+						"visibility": {
 							Type:     schema.TypeString,
 							Computed: true,
 						},
+						"code": {
+							Type:       schema.TypeString,
+							Computed:   true,
+							Deprecated: "`code` is deprecated and will be removed in the next major version, use `name` instead",
+						},
+						"country": {
+							Type:       schema.TypeString,
+							Computed:   true,
+							Deprecated: "`country` is deprecated and will be removed in the next major version: the API doesn't return it, use `region` instead",
+						},
+						"city": {
+							Type:       schema.TypeString,
+							Computed:   true,
+							Deprecated: "`city` is deprecated and will be removed in the next major version: the API doesn't return it, use `region` instead",
+						},
+						"datacenter": {
+							Type:       schema.TypeString,
+							Computed:   true,
+							Deprecated: "`datacenter` is deprecated and will be removed in the next major version, use `description` instead",
+						},
 					},
 				},
 			},
@@ -66,12 +102,41 @@ func dataSourceDedicatedServerLocationsV1Read(ctx context.Context, d *schema.Res
 		return diag.FromErr(errGettingObjects("server locations", err))
 	}
 
+	name := expandServerLocationsFilterName(d.Get("filter").(*schema.Set))
+	if name != "" {
+		locations = filterServerLocationsByName(locations, name)
+	}
+
 	locationsFlattened := flattenServerLocations(locations)
 	if err := d.Set("locations", locationsFlattened); err != nil {
 		return diag.FromErr(err)
 	}
 
-	d.SetId("server-locations")
+	d.SetId(fmt.Sprintf("server-locations-%s", name))
 
 	return nil
 }
+
+// expandServerLocationsFilterName извлекает имя локации из фильтра
+func expandServerLocationsFilterName(filterSet *schema.Set) string {
+	if filterSet.Len() == 0 {
+		return ""
+	}
+
+	name, _ := filterSet.List()[0].(map[string]interface{})["name"].(string)
+
+	return name
+}
+
+// filterServerLocationsByName оставляет локации с указанным именем без
+// учета регистра
+func filterServerLocationsByName(locations []*servers.ServerLocation, name string) []*servers.ServerLocation {
+	filtered := make([]*servers.ServerLocation, 0, 1)
+	for _, location := range locations {
+		if strings.EqualFold(location.Name, name) {
+			filtered = append(filtered, location)
+		}
+	}
+
+	return filtered
+}
@@ -0,0 +1,81 @@
+package selectel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/fakeservers"
+)
+
+func TestAccDataSourceDedicatedServerLocationsV1Name(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccSelectelDedicatedServersPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceDedicatedServerLocationsV1NameConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.selectel_dedicated_server_locations_v1.spb_4", "locations.#", "1"),
+					resource.TestCheckResourceAttr("data.selectel_dedicated_server_locations_v1.spb_4", "locations.0.name", "SPB-4"),
+					resource.TestCheckResourceAttrSet("data.selectel_dedicated_server_locations_v1.spb_4", "locations.0.uuid"),
+					resource.TestCheckResourceAttrSet("data.selectel_dedicated_server_locations_v1.spb_4", "locations.0.region"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceDedicatedServerLocationsV1NameFake(t *testing.T) {
+	api := fakeservers.New()
+	defer api.Close()
+
+	api.AddLocation(fakeservers.Location{
+		UUID:       "0b1f3a57-9b67-5bd6-a7a1-3f0bd5cc2f73",
+		Name:       "MSK-2",
+		LocationID: 2,
+		Region:     "ru-2",
+		Visibility: "public",
+	})
+
+	ctx := context.Background()
+	meta := testServersFakeConfig(t, api)
+
+	all := schema.TestResourceDataRaw(t, dataSourceDedicatedServerLocationsV1().Schema, map[string]interface{}{})
+	diags := dataSourceDedicatedServerLocationsV1Read(ctx, all, meta)
+	require.False(t, diags.HasError(), diags)
+	assert.Len(t, all.Get("locations"), 2)
+
+	d := schema.TestResourceDataRaw(t, dataSourceDedicatedServerLocationsV1().Schema, map[string]interface{}{
+		"filter": []interface{}{map[string]interface{}{"name": "spb-4"}},
+	})
+	diags = dataSourceDedicatedServerLocationsV1Read(ctx, d, meta)
+	require.False(t, diags.HasError(), diags)
+
+	locations := d.Get("locations").([]interface{})
+	require.Len(t, locations, 1)
+	assert.Equal(t, map[string]interface{}{
+		"id":          fakeservers.LocationID,
+		"uuid":        fakeservers.LocationUUID,
+		"name":        fakeservers.LocationName,
+		"description": "Saint Petersburg, Dubrovka",
+		"region":      "ru-1",
+		"dc_count":    1,
+		"visibility":  "public",
+		"code":        "SPB",
+		"country":     "",
+		"city":        "",
+		"datacenter":  "Saint Petersburg, Dubrovka",
+	}, locations[0])
+}
+
+const testAccDataSourceDedicatedServerLocationsV1NameConfig = `
+data "selectel_dedicated_server_locations_v1" "spb_4" {
+  filter {
+    name = "SPB-4"
+  }
+}
+`
@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/servers"
 )
 
 func dataSourceDedicatedServerOSV1() *schema.Resource {
@@ -67,7 +68,7 @@ func dataSourceDedicatedServerOSV1Read(ctx context.Context, d *schema.ResourceDa
 
 	log.Printf("[DEBUG] Reading %s list", objectServerOS)
 
-	var operatingSystems []*ServerOS
+	var operatingSystems []*servers.ServerOS
 
 	// Проверяем, предоставлены ли параметры для нового эндпоинта
 	locationUUID, hasLocationUUID := d.GetOk("location_uuid")
@@ -76,7 +77,10 @@ func dataSourceDedicatedServerOSV1Read(ctx context.Context, d *schema.ResourceDa
 	if hasLocationUUID && hasServiceUUID {
 		// Используем новый эндпоинт с параметрами
 		log.Printf("[DEBUG] Using new OS endpoint with location_uuid=%s, service_uuid=%s", locationUUID.(string), serviceUUID.(string))
-		operatingSystems, err = serversService.ListOperatingSystemsNew(ctx, locationUUID.(string), serviceUUID.(string))
+		operatingSystems, err = serversService.ListOperatingSystemsNew(ctx, &servers.OperatingSystemsListOpts{
+			LocationUUID: locationUUID.(string),
+			ServiceUUID:  serviceUUID.(string),
+		})
 	} else {
 		// Используем старый эндпоинт для обратной совместимости
 		log.Printf("[DEBUG] Using legacy OS endpoint")
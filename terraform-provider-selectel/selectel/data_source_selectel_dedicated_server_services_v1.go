@@ -6,6 +6,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/servers"
 )
 
 func dataSourceDedicatedServerServicesV1() *schema.Resource {
@@ -59,7 +60,7 @@ func dataSourceDedicatedServerServicesV1Read(ctx context.Context, d *schema.Reso
 	}
 
 	// Фильтруем только активные сервисы
-	var activeServices []*ServerService
+	var activeServices []*servers.ServerService
 	for _, service := range allServices {
 		if service.State == "Active" {
 			activeServices = append(activeServices, service)
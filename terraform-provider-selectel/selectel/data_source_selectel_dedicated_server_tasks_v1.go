@@ -8,6 +8,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/servers"
 )
 
 func dataSourceDedicatedServerTasksV1() *schema.Resource {
@@ -87,7 +88,7 @@ func dataSourceDedicatedServerTasksV1Read(ctx context.Context, d *schema.Resourc
 			return diag.FromErr(fmt.Errorf("error reading task %d: %s", taskIDInt, err))
 		}
 
-		tasks := []*ServerTaskStatus{task}
+		tasks := []*servers.ServerTaskStatus{task}
 		tasksFlattened := flattenServerTasks(tasks)
 		if err := d.Set("tasks", tasksFlattened); err != nil {
 			return diag.FromErr(err)
@@ -111,7 +112,7 @@ func dataSourceDedicatedServerTasksV1Read(ctx context.Context, d *schema.Resourc
 }
 
 // flattenServerTasks преобразует массив ServerTaskStatus в формат для Terraform
-func flattenServerTasks(tasks []*ServerTaskStatus) []interface{} {
+func flattenServerTasks(tasks []*servers.ServerTaskStatus) []interface{} {
 	if tasks == nil {
 		return []interface{}{}
 	}
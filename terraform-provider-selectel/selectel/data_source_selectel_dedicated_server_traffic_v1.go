@@ -0,0 +1,127 @@
+package selectel
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/servers"
+)
+
+func dataSourceDedicatedServerTrafficV1() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDedicatedServerTrafficV1Read,
+		Schema: map[string]*schema.Schema{
+			"server_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID or UUID of the server",
+			},
+			"period": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringMatch(
+					regexp.MustCompile(`^\d{4}-(0[1-9]|1[0-2])$`), "period must be in the YYYY-MM format",
+				),
+				Description: "Billing period in the YYYY-MM format. The current period is used if not set",
+			},
+			"period_start": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Start of the billing period",
+			},
+			"period_end": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "End of the billing period",
+			},
+			"inbound_bytes": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Inbound traffic consumed in the period",
+			},
+			"outbound_bytes": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Outbound traffic consumed in the period",
+			},
+			"quota_bytes": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Committed traffic quota for the period, 0 if the traffic is unmetered",
+			},
+			"ports": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"mac_address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"inbound_bytes": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"outbound_bytes": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+				Description: "Traffic per switch port, if reported by the API",
+			},
+		},
+	}
+}
+
+func dataSourceDedicatedServerTrafficV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	serversService, err := config.GetServersService()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	serverID := d.Get("server_id").(string)
+	period := d.Get("period").(string)
+
+	log.Print(msgGet(objectServerTraffic, serverID))
+
+	traffic, err := serversService.GetServerTraffic(ctx, serverID, &servers.ServerTrafficOpts{Period: period})
+	if err != nil {
+		return diag.FromErr(errGettingObject(objectServerTraffic, serverID, err))
+	}
+	if traffic == nil {
+		return diag.FromErr(errReadFromResponse(objectServerTraffic))
+	}
+
+	id := serverID
+	if period != "" {
+		id = fmt.Sprintf("%s/%s", serverID, period)
+	}
+	d.SetId(id)
+
+	if traffic.PeriodStart != nil {
+		d.Set("period_start", traffic.PeriodStart.Format("2006-01-02T15:04:05Z"))
+	}
+	if traffic.PeriodEnd != nil {
+		d.Set("period_end", traffic.PeriodEnd.Format("2006-01-02T15:04:05Z"))
+	}
+	d.Set("inbound_bytes", int(traffic.InboundBytes))
+	d.Set("outbound_bytes", int(traffic.OutboundBytes))
+	d.Set("quota_bytes", int(traffic.QuotaBytes))
+
+	if err := d.Set("ports", flattenServerPortsTraffic(traffic.Ports)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
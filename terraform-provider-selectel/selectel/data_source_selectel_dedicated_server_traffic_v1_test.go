@@ -0,0 +1,92 @@
+package selectel
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/fakeservers"
+)
+
+func TestAccDataSourceDedicatedServerTrafficV1Basic(t *testing.T) {
+	serverID := testAccSelectelDedicatedServerIDForTests()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccSelectelDedicatedServersPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceDedicatedServerTrafficV1Config(serverID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.selectel_dedicated_server_traffic_v1.traffic_1", "server_id", serverID),
+					resource.TestCheckResourceAttrSet("data.selectel_dedicated_server_traffic_v1.traffic_1", "inbound_bytes"),
+					resource.TestCheckResourceAttrSet("data.selectel_dedicated_server_traffic_v1.traffic_1", "outbound_bytes"),
+					resource.TestCheckResourceAttrSet("data.selectel_dedicated_server_traffic_v1.traffic_1", "quota_bytes"),
+					resource.TestCheckResourceAttrSet("data.selectel_dedicated_server_traffic_v1.traffic_1", "period_start"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceDedicatedServerTrafficV1Period(t *testing.T) {
+	serverID := testAccSelectelDedicatedServerIDForTests()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccSelectelDedicatedServersPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceDedicatedServerTrafficV1PeriodConfig(serverID, "2025-01"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.selectel_dedicated_server_traffic_v1.traffic_period", "period", "2025-01"),
+					resource.TestCheckResourceAttrSet("data.selectel_dedicated_server_traffic_v1.traffic_period", "ports.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceDedicatedServerTrafficV1IDFake(t *testing.T) {
+	api := fakeservers.New()
+	defer api.Close()
+
+	server := api.AddServer(fakeservers.Server{Name: "storage-1"})
+	serverID := strconv.Itoa(server.ID)
+	meta := testServersFakeConfig(t, api)
+
+	d := schema.TestResourceDataRaw(t, dataSourceDedicatedServerTrafficV1().Schema, map[string]interface{}{
+		"server_id": serverID,
+	})
+	diags := dataSourceDedicatedServerTrafficV1Read(context.Background(), d, meta)
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, serverID, d.Id())
+
+	d = schema.TestResourceDataRaw(t, dataSourceDedicatedServerTrafficV1().Schema, map[string]interface{}{
+		"server_id": serverID,
+		"period":    "2025-01",
+	})
+	diags = dataSourceDedicatedServerTrafficV1Read(context.Background(), d, meta)
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, serverID+"/2025-01", d.Id())
+}
+
+func testAccDataSourceDedicatedServerTrafficV1Config(serverID string) string {
+	return fmt.Sprintf(`
+data "selectel_dedicated_server_traffic_v1" "traffic_1" {
+  server_id = "%s"
+}`, serverID)
+}
+
+func testAccDataSourceDedicatedServerTrafficV1PeriodConfig(serverID, period string) string {
+	return fmt.Sprintf(`
+data "selectel_dedicated_server_traffic_v1" "traffic_period" {
+  server_id = "%s"
+  period    = "%s"
+}`, serverID, period)
+}
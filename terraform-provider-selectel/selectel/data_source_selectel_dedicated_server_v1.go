@@ -141,26 +141,50 @@ func dataSourceDedicatedServerV1() *schema.Resource {
 							Type:     schema.TypeInt,
 							Computed: true,
 						},
-						"name": {
+						"uuid": {
 							Type:     schema.TypeString,
 							Computed: true,
 						},
-						"code": {
+						"name": {
 							Type:     schema.TypeString,
 							Computed: true,
 						},
-						"country": {
+						"description": {
 							Type:     schema.TypeString,
 							Computed: true,
 						},
-						"city": {
+						"region": {
 							Type:     schema.TypeString,
 							Computed: true,
 						},
-						"datacenter": {
+						"dc_count": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"visibility": {
 							Type:     schema.TypeString,
 							Computed: true,
 						},
+						"code": {
+							Type:       schema.TypeString,
+							Computed:   true,
+							Deprecated: "`code` is deprecated and will be removed in the next major version, use `name` instead",
+						},
+						"country": {
+							Type:       schema.TypeString,
+							Computed:   true,
+							Deprecated: "`country` is deprecated and will be removed in the next major version: the API doesn't return it, use `region` instead",
+						},
+						"city": {
+							Type:       schema.TypeString,
+							Computed:   true,
+							Deprecated: "`city` is deprecated and will be removed in the next major version: the API doesn't return it, use `region` instead",
+						},
+						"datacenter": {
+							Type:       schema.TypeString,
+							Computed:   true,
+							Deprecated: "`datacenter` is deprecated and will be removed in the next major version, use `description` instead",
+						},
 					},
 				},
 			},
@@ -275,6 +299,27 @@ func dataSourceDedicatedServerV1() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"ssh_keys": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"uuid": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"fingerprint": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+				Description: "SSH keys installed on the server",
+			},
 		},
 	}
 }
@@ -365,5 +410,9 @@ func dataSourceDedicatedServerV1Read(ctx context.Context, d *schema.ResourceData
 		return diag.FromErr(err)
 	}
 
+	if err := d.Set("ssh_keys", flattenServerSSHKeys(server.SSHKeys)); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return nil
 }
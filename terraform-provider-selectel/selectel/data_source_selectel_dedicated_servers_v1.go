@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/servers"
 )
 
 func dataSourceDedicatedServersV1() *schema.Resource {
@@ -91,18 +92,50 @@ func dataSourceDedicatedServersV1() *schema.Resource {
 										Type:     schema.TypeInt,
 										Computed: true,
 									},
+									"uuid": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
 									"name": {
 										Type:     schema.TypeString,
 										Computed: true,
 									},
-									"code": {
+									"description": {
 										Type:     schema.TypeString,
 										Computed: true,
 									},
-									"city": {
+									"region": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"dc_count": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+									"visibility": {
 										Type:     schema.TypeString,
 										Computed: true,
 									},
+									"code": {
+										Type:       schema.TypeString,
+										Computed:   true,
+										Deprecated: "`code` is deprecated and will be removed in the next major version, use `name` instead",
+									},
+									"country": {
+										Type:       schema.TypeString,
+										Computed:   true,
+										Deprecated: "`country` is deprecated and will be removed in the next major version: the API doesn't return it, use `region` instead",
+									},
+									"city": {
+										Type:       schema.TypeString,
+										Computed:   true,
+										Deprecated: "`city` is deprecated and will be removed in the next major version: the API doesn't return it, use `region` instead",
+									},
+									"datacenter": {
+										Type:       schema.TypeString,
+										Computed:   true,
+										Deprecated: "`datacenter` is deprecated and will be removed in the next major version, use `description` instead",
+									},
 								},
 							},
 						},
@@ -121,6 +154,13 @@ func dataSourceDedicatedServersV1() *schema.Resource {
 								Type: schema.TypeString,
 							},
 						},
+						"labels": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
 					},
 				},
 			},
@@ -141,6 +181,12 @@ func dataSourceDedicatedServersV1() *schema.Resource {
 							Type:     schema.TypeString,
 							Optional: true,
 						},
+						"label_selector": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateServerLabelSelector,
+							Description:  "Comma-separated label requirements, e.g. \"env=prod,role!=db,team\"",
+						},
 					},
 				},
 			},
@@ -161,28 +207,38 @@ func dataSourceDedicatedServersV1Read(ctx context.Context, d *schema.ResourceDat
 	filter := expandServersFilter(d.Get("filter").(*schema.Set))
 
 	// Формируем опции запроса
-	opts := &ServersListOptions{
-		Status:   filter.Status,
-		Location: filter.Location,
+	opts := &servers.ServersListOptions{
+		Status:        filter.Status,
+		Location:      filter.Location,
+		LabelSelector: filter.LabelSelector,
 	}
 
-	servers, err := serversService.ListServers(ctx, opts)
+	labelRequirements, err := parseServerLabelSelector(filter.LabelSelector)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	serverList, err := serversService.ListServers(ctx, opts)
 	if err != nil {
 		return diag.FromErr(errGettingObjects("dedicated servers", err))
 	}
 
-	// Дополнительная фильтрация по имени (если API не поддерживает)
-	if filter.Name != "" {
-		filteredServers := make([]*DedicatedServer, 0)
-		for _, server := range servers {
-			if server.Name == filter.Name {
-				filteredServers = append(filteredServers, server)
+	// Дополнительная фильтрация по имени и меткам (если API не поддерживает)
+	if filter.Name != "" || len(labelRequirements) > 0 {
+		filteredServers := make([]*servers.DedicatedServer, 0)
+		for _, server := range serverList {
+			if filter.Name != "" && server.Name != filter.Name {
+				continue
+			}
+			if !matchServerLabels(labelRequirements, server.Labels) {
+				continue
 			}
+			filteredServers = append(filteredServers, server)
 		}
-		servers = filteredServers
+		serverList = filteredServers
 	}
 
-	serversFlattened := flattenDedicatedServers(servers)
+	serversFlattened := flattenDedicatedServers(serverList)
 	if err := d.Set("servers", serversFlattened); err != nil {
 		return diag.FromErr(err)
 	}
@@ -198,6 +254,8 @@ type serversFilter struct {
 	Status   string
 	Location string
 	Name     string
+
+	LabelSelector string
 }
 
 // expandServersFilter извлекает параметры фильтра из схемы
@@ -221,10 +279,14 @@ func expandServersFilter(filterSet *schema.Set) serversFilter {
 		filter.Name = name
 	}
 
+	if labelSelector, ok := resourceFilterMap["label_selector"].(string); ok {
+		filter.LabelSelector = labelSelector
+	}
+
 	return filter
 }
 
 // buildServersFilterID создает уникальный ID для набора фильтров
 func buildServersFilterID(filter serversFilter) string {
-	return fmt.Sprintf("servers-%s-%s-%s", filter.Status, filter.Location, filter.Name)
+	return fmt.Sprintf("servers-%s-%s-%s-%s", filter.Status, filter.Location, filter.Name, filter.LabelSelector)
 }
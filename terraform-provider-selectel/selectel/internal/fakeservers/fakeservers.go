@@ -0,0 +1,531 @@
+// Package fakeservers implements an in-process stand-in for the Selectel
+// dedicated servers API. It is meant for unit tests that exercise the servers
+// client, resources and data sources without a live account.
+package fakeservers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultToken is the token the fake accepts unless API.Token is changed.
+const DefaultToken = "fake-servers-token"
+
+// basePath is the path prefix of the servers API, as in the real endpoint.
+const basePath = "/servers/v2/"
+
+// Fixture identifiers that are always present in a new fake.
+const (
+	LocationUUID    = "b7d55bf4-7057-5113-85c8-141871bf7635"
+	LocationName    = "SPB-4"
+	LocationID      = 1
+	ServiceUUID     = "7a7d09db-0915-46a1-93f6-5e3024709325"
+	ServiceName     = "AR21-SSD"
+	OSID            = 5
+	ConfigurationID = 1
+
+	// DefaultStock is the number of fixture servers in stock.
+	DefaultStock = 5
+)
+
+// Hook changes the response of the requests it matches. A hook matches when
+// the method is equal (or empty) and the request path, relative to the API
+// base, has Path as a prefix.
+type Hook struct {
+	Method string
+	Path   string
+
+	// Delay is applied before the request is handled.
+	Delay time.Duration
+
+	// Status, when non-zero, replaces the response with an API error.
+	// ErrorCode is returned as error_code, e.g. "OUT_OF_STOCK".
+	Status    int
+	ErrorCode string
+	Message   string
+
+	// Times limits the number of requests the hook applies to. Zero means
+	// the hook never expires.
+	Times int
+}
+
+// API is the fake servers API. All exported methods are safe for concurrent
+// use with the HTTP handlers.
+type API struct {
+	// URL is the base URL to pass as servers.ServersClientOptions.BaseURL.
+	URL string
+
+	// Token is the expected X-Auth-Token value.
+	Token string
+
+	// TaskSteps is the number of reads after which a task completes.
+	TaskSteps int
+
+	server *httptest.Server
+
+	mu             sync.Mutex
+	nextID         int
+	servers        map[int]*Server
+	tasks          map[int]*Task
+	locations      []*Location
+	osTemplates    []*OS
+	services       []*Service
+	availability   []*Availability
+	configurations []*Configuration
+	sshKeys        map[string]*SSHKey
+	customOS       map[string]*CustomOSTemplate
+	billing        map[string]*Billing
+	locks          map[string]bool
+	provisioning   map[string]bool
+	hardware       map[int]*Hardware
+	traffic        map[int]*Traffic
+	orders         []Order
+	hooks          []*Hook
+	requests       []Request
+}
+
+// New starts a fake servers API populated with a location, an OS template,
+// a service and a configuration. Call Close when done.
+func New() *API {
+	dcCount := 1
+	api := &API{
+		Token:     DefaultToken,
+		TaskSteps: 1,
+		nextID:    1000,
+		servers:   map[int]*Server{},
+		tasks:     map[int]*Task{},
+		locations: []*Location{{
+			UUID:        LocationUUID,
+			Name:        LocationName,
+			LocationID:  LocationID,
+			Description: "Saint Petersburg, Dubrovka",
+			Region:      "ru-1",
+			DCCount:     &dcCount,
+			Visibility:  "public",
+		}},
+		osTemplates: []*OS{{
+			ID:           OSID,
+			Name:         "Debian",
+			Version:      "12",
+			Architecture: "x86_64",
+			Type:         "linux",
+			Distribution: "debian",
+		}},
+		services: []*Service{{
+			ID:    "1",
+			UUID:  ServiceUUID,
+			Name:  ServiceName,
+			Type:  "serverchip",
+			State: "active",
+		}},
+		availability: []*Availability{{
+			ServiceUUID:  ServiceUUID,
+			ConfigID:     ConfigurationID,
+			LocationUUID: LocationUUID,
+			Quantity:     DefaultStock,
+		}},
+		configurations: []*Configuration{{
+			ID:          ConfigurationID,
+			Name:        ServiceName,
+			LocationIDs: []int{LocationID},
+			Available:   true,
+		}},
+		sshKeys:      map[string]*SSHKey{},
+		customOS:     map[string]*CustomOSTemplate{},
+		billing:      map[string]*Billing{},
+		locks:        map[string]bool{},
+		provisioning: map[string]bool{},
+		hardware:     map[int]*Hardware{},
+		traffic:      map[int]*Traffic{},
+	}
+
+	api.server = httptest.NewServer(http.HandlerFunc(api.serveHTTP))
+	api.URL = api.server.URL + basePath
+
+	return api
+}
+
+// Close shuts the fake down.
+func (a *API) Close() {
+	a.server.Close()
+}
+
+// AddHook registers a hook to inject failures or delays.
+func (a *API) AddHook(h Hook) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	hook := h
+	a.hooks = append(a.hooks, &hook)
+}
+
+// AddServer stores a server and returns it with ID, UUID and timestamps set.
+func (a *API) AddServer(s Server) *Server {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.addServerLocked(s)
+}
+
+// AddLocation stores an additional location.
+func (a *API) AddLocation(l Location) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	location := l
+	a.locations = append(a.locations, &location)
+}
+
+// AddService stores an additional service.
+func (a *API) AddService(s Service) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	service := s
+	a.services = append(a.services, &service)
+}
+
+// SetAvailability sets the stock of a service in a location, replacing the
+// previous record for the pair.
+func (a *API) SetAvailability(av Availability) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	availability := av
+	for i, existing := range a.availability {
+		if existing.ServiceUUID == av.ServiceUUID && existing.LocationUUID == av.LocationUUID {
+			a.availability[i] = &availability
+			return
+		}
+	}
+	a.availability = append(a.availability, &availability)
+}
+
+// AddAvailability adds a stock record without replacing the records already
+// stored for the pair, e.g. a row that is not bound to a configuration.
+func (a *API) AddAvailability(av Availability) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	availability := av
+	a.availability = append(a.availability, &availability)
+}
+
+// Server returns a copy of the stored server with the given ID or UUID.
+func (a *API) Server(id string) (Server, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	s := a.findServerLocked(id)
+	if s == nil {
+		return Server{}, false
+	}
+
+	return *s, true
+}
+
+// SetServerStatus changes the status of a server, e.g. to simulate a power
+// off from the panel. The status is mirrored to the billing record if the
+// server has one.
+func (a *API) SetServerStatus(id, status string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if s := a.findServerLocked(id); s != nil {
+		s.Status = status
+		if b, ok := a.billing[s.UUID]; ok {
+			b.Status = status
+		}
+	}
+}
+
+// SetProvisioning marks an ordered server as still being provisioned. Such a
+// server has a billing record, but the server endpoints do not show it yet.
+func (a *API) SetProvisioning(uuid string, provisioning bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.provisioning[uuid] = provisioning
+}
+
+// RemoveServer deletes a server as if it was cancelled out of band.
+func (a *API) RemoveServer(id string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if s := a.findServerLocked(id); s != nil {
+		delete(a.servers, s.ID)
+		delete(a.billing, s.UUID)
+		delete(a.locks, s.UUID)
+	}
+}
+
+// AddTask stores a task. Tasks added this way fail with failMessage if it is
+// not empty.
+func (a *API) AddTask(failMessage string) *Task {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	task := a.addTaskLocked(0, "")
+	task.fail = failMessage
+
+	return task
+}
+
+// SetHardware sets the hardware inventory of a server.
+func (a *API) SetHardware(serverID int, hw *Hardware) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.hardware[serverID] = hw
+}
+
+// SetTraffic sets the traffic usage of a server.
+func (a *API) SetTraffic(serverID int, traffic *Traffic) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.traffic[serverID] = traffic
+}
+
+// Billing returns a copy of the rental state of a server.
+func (a *API) Billing(uuid string) (Billing, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	b, ok := a.billing[uuid]
+	if !ok {
+		return Billing{}, false
+	}
+
+	return *b, true
+}
+
+// CustomOSTemplate returns a copy of the custom OS template with the given
+// UUID.
+func (a *API) CustomOSTemplate(uuid string) (CustomOSTemplate, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	t, ok := a.customOS[uuid]
+	if !ok {
+		return CustomOSTemplate{}, false
+	}
+
+	return *t, true
+}
+
+// Locked reports whether the server-side deletion lock is set.
+func (a *API) Locked(uuid string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.locks[uuid]
+}
+
+// Orders returns the request bodies received by the billing order endpoint.
+func (a *API) Orders() []Order {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return append([]Order(nil), a.orders...)
+}
+
+// Requests returns all requests received so far.
+func (a *API) Requests() []Request {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return append([]Request(nil), a.requests...)
+}
+
+func (a *API) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, basePath)
+	body, _ := io.ReadAll(r.Body)
+
+	a.mu.Lock()
+	a.requests = append(a.requests, Request{
+		Method: r.Method,
+		Path:   path,
+		Query:  r.URL.RawQuery,
+		Body:   string(body),
+	})
+	w.Header().Set("X-Request-Id", fmt.Sprintf("req-%d", len(a.requests)))
+	hook := a.matchHookLocked(r.Method, path)
+	a.mu.Unlock()
+
+	if hook != nil {
+		if hook.Delay > 0 {
+			select {
+			case <-time.After(hook.Delay):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		if hook.Status != 0 {
+			message := hook.Message
+			if message == "" {
+				message = http.StatusText(hook.Status)
+			}
+			writeJSON(w, hook.Status, map[string]interface{}{
+				"code":       hook.Status,
+				"error_code": hook.ErrorCode,
+				"message":    message,
+			})
+			return
+		}
+	}
+
+	if r.Header.Get("X-Auth-Token") != a.token() {
+		writeError(w, http.StatusUnauthorized, "invalid token")
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.route(w, r, strings.Split(strings.Trim(path, "/"), "/"), body)
+}
+
+// SetToken replaces the accepted token, e.g. to simulate expiry of the
+// token a client holds.
+func (a *API) SetToken(token string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.Token = token
+}
+
+func (a *API) token() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return a.Token
+}
+
+func (a *API) matchHookLocked(method, path string) *Hook {
+	for i, h := range a.hooks {
+		if h.Method != "" && h.Method != method {
+			continue
+		}
+		if !strings.HasPrefix(path, h.Path) {
+			continue
+		}
+		if h.Times > 0 {
+			h.Times--
+			if h.Times == 0 {
+				a.hooks = append(a.hooks[:i], a.hooks[i+1:]...)
+			}
+		}
+
+		return h
+	}
+
+	return nil
+}
+
+func (a *API) addServerLocked(s Server) *Server {
+	a.nextID++
+	if s.ID == 0 {
+		s.ID = a.nextID
+	}
+	if s.UUID == "" {
+		s.UUID = fakeUUID(s.ID)
+	}
+	if s.Status == "" {
+		s.Status = "active"
+	}
+	if s.Location == nil {
+		location := *a.locations[0]
+		s.Location = &location
+	}
+	now := time.Now().UTC().Truncate(time.Second)
+	if s.CreatedAt == nil {
+		s.CreatedAt = &now
+	}
+	s.UpdatedAt = &now
+
+	server := s
+	a.servers[server.ID] = &server
+
+	return &server
+}
+
+func (a *API) findServerLocked(id string) *Server {
+	for _, s := range a.servers {
+		if fmt.Sprint(s.ID) == id || s.UUID == id {
+			return s
+		}
+	}
+
+	return nil
+}
+
+func (a *API) addTaskLocked(serverID int, targetStatus string) *Task {
+	a.nextID++
+	task := &Task{
+		ID:           a.nextID,
+		Status:       "pending",
+		CreatedAt:    time.Now().UTC().Truncate(time.Second),
+		serverID:     serverID,
+		targetStatus: targetStatus,
+	}
+	a.tasks[task.ID] = task
+
+	return task
+}
+
+// advanceTaskLocked moves a task one step towards completion and applies its
+// effect on the server once it is done.
+func (a *API) advanceTaskLocked(task *Task) {
+	if task.Status == "completed" || task.Status == "failed" {
+		return
+	}
+
+	steps := a.TaskSteps
+	if steps < 1 {
+		steps = 1
+	}
+	task.Progress += 100 / steps
+	task.Status = "running"
+	if task.Progress < 100 {
+		return
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	task.Progress = 100
+	task.CompletedAt = &now
+	if task.fail != "" {
+		task.Status = "failed"
+		task.Error = task.fail
+		return
+	}
+	task.Status = "completed"
+
+	if s, ok := a.servers[task.serverID]; ok && task.targetStatus != "" {
+		s.Status = task.targetStatus
+	}
+}
+
+func fakeUUID(id int) string {
+	return fmt.Sprintf("00000000-0000-4000-8000-%012d", id)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"code":    status,
+		"message": message,
+	})
+}
@@ -0,0 +1,142 @@
+package fakeservers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func doRequest(t *testing.T, api *API, method, path, body string) (int, map[string]interface{}) {
+	t.Helper()
+
+	req, err := http.NewRequest(method, api.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("X-Auth-Token", api.Token)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var result map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatal(err)
+	}
+
+	return resp.StatusCode, result
+}
+
+func TestUnauthorized(t *testing.T) {
+	api := New()
+	defer api.Close()
+
+	resp, err := http.Get(api.URL + "location")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", resp.StatusCode)
+	}
+}
+
+func TestTaskProgress(t *testing.T) {
+	api := New()
+	defer api.Close()
+	api.TaskSteps = 2
+
+	server := api.AddServer(Server{Name: "node-1"})
+
+	status, result := doRequest(t, api, http.MethodPost, "server/"+strconv.Itoa(server.ID)+"/action", `{"action":"stop"}`)
+	if status != http.StatusOK {
+		t.Fatalf("unexpected status %d", status)
+	}
+	taskID := int(result["data"].(map[string]interface{})["id"].(float64))
+
+	_, result = doRequest(t, api, http.MethodGet, "task/"+strconv.Itoa(taskID), "")
+	task := result["data"].(map[string]interface{})
+	if task["status"] != "running" || task["progress"].(float64) != 50 {
+		t.Fatalf("unexpected task after first read: %v", task)
+	}
+
+	status, _ = doRequest(t, api, http.MethodPost, "server/"+strconv.Itoa(server.ID)+"/action", `{"action":"start"}`)
+	if status != http.StatusConflict {
+		t.Fatalf("expected a conflict while the task is running, got %d", status)
+	}
+
+	_, result = doRequest(t, api, http.MethodGet, "task/"+strconv.Itoa(taskID), "")
+	task = result["data"].(map[string]interface{})
+	if task["status"] != "completed" {
+		t.Fatalf("unexpected task after second read: %v", task)
+	}
+
+	if s, _ := api.Server(strconv.Itoa(server.ID)); s.Status != "stopped" {
+		t.Fatalf("expected server to be stopped, got %s", s.Status)
+	}
+}
+
+func TestHooks(t *testing.T) {
+	api := New()
+	defer api.Close()
+
+	api.AddHook(Hook{Method: http.MethodGet, Path: "location", Status: http.StatusServiceUnavailable, Times: 1})
+	api.AddHook(Hook{Path: "os", Delay: 50 * time.Millisecond})
+
+	status, result := doRequest(t, api, http.MethodGet, "location", "")
+	if status != http.StatusServiceUnavailable || result["code"].(float64) != http.StatusServiceUnavailable {
+		t.Fatalf("expected injected failure, got %d %v", status, result)
+	}
+
+	status, _ = doRequest(t, api, http.MethodGet, "location", "")
+	if status != http.StatusOK {
+		t.Fatalf("expected hook to expire, got %d", status)
+	}
+
+	start := time.Now()
+	doRequest(t, api, http.MethodGet, "os", "")
+	if time.Since(start) < 50*time.Millisecond {
+		t.Fatal("expected delayed response")
+	}
+}
+
+func TestOrderAndCancel(t *testing.T) {
+	api := New()
+	defer api.Close()
+
+	status, result := doRequest(t, api, http.MethodPost, "resource/serverchip/billing",
+		`{"name":"node-1","location_uuid":"`+LocationUUID+`","service_uuid":"`+ServiceUUID+`"}`)
+	if status != http.StatusOK {
+		t.Fatalf("unexpected status %d: %v", status, result)
+	}
+	uuid := result["result"].([]interface{})[0].(map[string]interface{})["uuid"].(string)
+
+	if _, ok := api.Billing(uuid); !ok {
+		t.Fatal("expected billing to be created")
+	}
+
+	doRequest(t, api, http.MethodPut, "resource/serverchip/"+uuid+"/lock", `{"locked":true}`)
+	status, _ = doRequest(t, api, http.MethodDelete, "resource/serverchip/billing/"+uuid+"?cancel_mode=immediate", "")
+	if status != http.StatusConflict {
+		t.Fatalf("expected locked server to refuse cancellation, got %d", status)
+	}
+
+	doRequest(t, api, http.MethodPut, "resource/serverchip/"+uuid+"/lock", `{"locked":false}`)
+	status, _ = doRequest(t, api, http.MethodDelete, "resource/serverchip/billing/"+uuid+"?cancel_mode=immediate", "")
+	if status != http.StatusOK {
+		t.Fatalf("unexpected status %d", status)
+	}
+	if _, ok := api.Server(uuid); ok {
+		t.Fatal("expected server to be removed")
+	}
+
+	if len(api.Orders()) != 1 {
+		t.Fatalf("expected one order, got %d", len(api.Orders()))
+	}
+}
@@ -0,0 +1,163 @@
+package fakeservers
+
+import "time"
+
+// The types below mirror the JSON payloads of the servers API. They are
+// declared independently of the servers package so that the fake checks the
+// wire format rather than the client's own structs.
+
+// Server is a dedicated server as returned by the server endpoints.
+type Server struct {
+	ID       int       `json:"id"`
+	UUID     string    `json:"uuid,omitempty"`
+	Name     string    `json:"name"`
+	Status   string    `json:"status"`
+	StatusHD string    `json:"status_hd,omitempty"`
+	Location *Location `json:"location,omitempty"`
+	OS       *OS       `json:"os,omitempty"`
+	Network  *Network  `json:"network,omitempty"`
+	Comment  string    `json:"comment,omitempty"`
+	Tags     []string  `json:"tags,omitempty"`
+	SSHKeys  []*SSHKey `json:"ssh_keys,omitempty"`
+
+	Labels map[string]string `json:"labels,omitempty"`
+
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// Network is the network block of a server.
+type Network struct {
+	PrimaryIP     string   `json:"primary_ip,omitempty"`
+	Gateway       string   `json:"gateway,omitempty"`
+	Netmask       string   `json:"netmask,omitempty"`
+	AdditionalIPs []string `json:"additional_ips,omitempty"`
+	Bandwidth     string   `json:"bandwidth,omitempty"`
+}
+
+// Task is an asynchronous server task. Progress grows on every read.
+type Task struct {
+	ID          int        `json:"id"`
+	Status      string     `json:"status"`
+	Progress    int        `json:"progress"`
+	Message     string     `json:"message,omitempty"`
+	Error       string     `json:"error,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+
+	serverID     int
+	targetStatus string
+	fail         string
+}
+
+// Location is a data center location.
+type Location struct {
+	UUID        string `json:"uuid"`
+	Name        string `json:"name"`
+	LocationID  int    `json:"location_id"`
+	Description string `json:"description"`
+	Region      string `json:"region"`
+	DCCount     *int   `json:"dc_count"`
+	Visibility  string `json:"visibility"`
+}
+
+// OS is an OS install template.
+type OS struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	Version      string `json:"version"`
+	Architecture string `json:"architecture"`
+	Type         string `json:"type"`
+	Distribution string `json:"distribution,omitempty"`
+}
+
+// Service is a billing service (server model) that can be ordered.
+type Service struct {
+	ID          string `json:"id"`
+	UUID        string `json:"uuid"`
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	State       string `json:"state,omitempty"`
+	Description string `json:"description,omitempty"`
+	Region      string `json:"region,omitempty"`
+}
+
+// Availability is the stock of a service in a location.
+type Availability struct {
+	ServiceUUID  string     `json:"service_uuid"`
+	ConfigID     int        `json:"config_id,omitempty"`
+	LocationUUID string     `json:"location_uuid"`
+	Quantity     int        `json:"quantity"`
+	RestockAt    *time.Time `json:"restock_at,omitempty"`
+}
+
+// Configuration is a server configuration.
+type Configuration struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	LocationIDs []int  `json:"location_ids"`
+	Available   bool   `json:"available"`
+}
+
+// CustomOSTemplate is a user-defined OS install template.
+type CustomOSTemplate struct {
+	UUID        string     `json:"uuid"`
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	BasedOn     string     `json:"based_on"`
+	Version     string     `json:"version,omitempty"`
+	Arch        string     `json:"arch,omitempty"`
+	InstallType string     `json:"install_type"`
+	Content     string     `json:"content"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+}
+
+// SSHKey is an SSH key registered in the account.
+type SSHKey struct {
+	UUID        string     `json:"uuid"`
+	Name        string     `json:"name"`
+	PublicKey   string     `json:"public_key,omitempty"`
+	Fingerprint string     `json:"fingerprint,omitempty"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+}
+
+// Billing is the rental state of an ordered server.
+type Billing struct {
+	UUID        string     `json:"uuid"`
+	Status      string     `json:"status,omitempty"`
+	Period      string     `json:"period"`
+	AutoRenewal bool       `json:"auto_renewal"`
+	PaidUntil   *time.Time `json:"paid_until,omitempty"`
+
+	// CancelAtPeriodEnd is set when the rental was cancelled with the
+	// period_end mode.
+	CancelAtPeriodEnd bool `json:"-"`
+}
+
+// Hardware is the hardware inventory of a server.
+type Hardware struct {
+	Disks    []map[string]interface{} `json:"disks,omitempty"`
+	NICs     []map[string]interface{} `json:"nics,omitempty"`
+	Firmware map[string]string        `json:"firmware,omitempty"`
+}
+
+// Traffic is the traffic usage of a server.
+type Traffic struct {
+	PeriodStart   *time.Time               `json:"period_start,omitempty"`
+	PeriodEnd     *time.Time               `json:"period_end,omitempty"`
+	InboundBytes  int64                    `json:"inbound_bytes"`
+	OutboundBytes int64                    `json:"outbound_bytes"`
+	QuotaBytes    int64                    `json:"quota_bytes"`
+	Ports         []map[string]interface{} `json:"ports,omitempty"`
+}
+
+// Order is a request body received by the billing order endpoint.
+type Order map[string]interface{}
+
+// Request is a recorded request to the fake.
+type Request struct {
+	Method string
+	Path   string
+	Query  string
+	Body   string
+}
@@ -0,0 +1,619 @@
+package fakeservers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// route dispatches a request. It is called with a.mu held.
+func (a *API) route(w http.ResponseWriter, r *http.Request, parts []string, body []byte) {
+	switch parts[0] {
+	case "server":
+		a.routeServer(w, r, parts[1:], body)
+	case "task":
+		a.routeTask(w, r, parts[1:])
+	case "configuration":
+		a.routeConfiguration(w, r, parts[1:])
+	case "location":
+		a.routeList(w, r, parts[1:], map[string]interface{}{
+			"task_id":    "",
+			"status":     "completed",
+			"progress":   100,
+			"page":       1,
+			"limit":      len(a.locations),
+			"item_count": len(a.locations),
+			"result":     a.locations,
+		})
+	case "os":
+		a.routeList(w, r, parts[1:], map[string]interface{}{"result": a.osTemplates})
+	case "service":
+		if len(parts) > 1 && parts[1] == "availability" {
+			a.routeList(w, r, parts[2:], map[string]interface{}{"result": a.filterAvailabilityLocked(r)})
+			return
+		}
+		a.routeList(w, r, parts[1:], map[string]interface{}{"result": a.services})
+	case "boot":
+		// boot/template/os/new, boot/template/os/custom[/uuid]
+		if len(parts) >= 4 && parts[3] == "custom" {
+			a.routeCustomOS(w, r, parts[4:], body)
+			return
+		}
+		a.routeList(w, r, nil, map[string]interface{}{"data": a.osTemplates})
+	case "resource":
+		a.routeResource(w, r, parts[1:], body)
+	case "ssh":
+		a.routeSSHKey(w, r, parts[1:], body)
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+func (a *API) routeList(w http.ResponseWriter, r *http.Request, rest []string, payload map[string]interface{}) {
+	if r.Method != http.MethodGet || len(rest) != 0 {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, payload)
+}
+
+func (a *API) routeServer(w http.ResponseWriter, r *http.Request, rest []string, body []byte) {
+	if len(rest) == 0 {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+
+		status := r.URL.Query().Get("status")
+		location := r.URL.Query().Get("location")
+		servers := make([]*Server, 0, len(a.servers))
+		for _, s := range a.servers {
+			if a.provisioning[s.UUID] {
+				continue
+			}
+			if status != "" && s.Status != status {
+				continue
+			}
+			if location != "" && (s.Location == nil || s.Location.Name != location) {
+				continue
+			}
+			servers = append(servers, s)
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": servers})
+
+		return
+	}
+
+	server := a.findServerLocked(rest[0])
+	if server == nil || a.provisioning[server.UUID] {
+		writeError(w, http.StatusNotFound, "server not found")
+		return
+	}
+
+	if len(rest) == 1 {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": server})
+		case http.MethodPatch:
+			var update struct {
+				Name    *string            `json:"name"`
+				Comment *string            `json:"comment"`
+				Tags    []string           `json:"tags"`
+				Labels  *map[string]string `json:"labels"`
+			}
+			if err := json.Unmarshal(body, &update); err != nil {
+				writeError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			if update.Name != nil {
+				server.Name = *update.Name
+			}
+			if update.Comment != nil {
+				server.Comment = *update.Comment
+			}
+			if update.Tags != nil {
+				server.Tags = update.Tags
+			}
+			if update.Labels != nil {
+				server.Labels = *update.Labels
+			}
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": server})
+		case http.MethodDelete:
+			delete(a.servers, server.ID)
+			writeJSON(w, http.StatusOK, map[string]interface{}{})
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+
+		return
+	}
+
+	switch rest[1] {
+	case "action":
+		a.serverAction(w, r, server, body)
+	case "hardware":
+		hw, ok := a.hardware[server.ID]
+		if !ok {
+			writeError(w, http.StatusNotFound, "hardware not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"result": hw})
+	case "traffic":
+		traffic, ok := a.traffic[server.ID]
+		if !ok {
+			traffic = &Traffic{}
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"result": traffic})
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+func (a *API) serverAction(w http.ResponseWriter, r *http.Request, server *Server, body []byte) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var action struct {
+		Action string `json:"action"`
+	}
+	if err := json.Unmarshal(body, &action); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Like the real API, a server runs one task at a time.
+	if server.Status == "rebooting" || server.Status == "installing" {
+		writeError(w, http.StatusConflict, "server has a running task")
+		return
+	}
+
+	var transitional, target string
+	switch action.Action {
+	case "start", "restart", "power_cycle":
+		transitional, target = "rebooting", "active"
+	case "stop":
+		transitional, target = "rebooting", "stopped"
+	case "reinstall":
+		transitional, target = "installing", "active"
+	default:
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("unknown action %q", action.Action))
+		return
+	}
+
+	server.Status = transitional
+	task := a.addTaskLocked(server.ID, target)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": task})
+}
+
+func (a *API) routeTask(w http.ResponseWriter, r *http.Request, rest []string) {
+	if r.Method != http.MethodGet || len(rest) != 1 {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	id, _ := strconv.Atoi(rest[0])
+	task, ok := a.tasks[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "task not found")
+		return
+	}
+
+	a.advanceTaskLocked(task)
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": task})
+}
+
+func (a *API) routeConfiguration(w http.ResponseWriter, r *http.Request, rest []string) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	if len(rest) == 0 {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": a.configurations})
+		return
+	}
+
+	for _, c := range a.configurations {
+		if strconv.Itoa(c.ID) == rest[0] {
+			writeJSON(w, http.StatusOK, map[string]interface{}{"data": c})
+			return
+		}
+	}
+
+	writeError(w, http.StatusNotFound, "configuration not found")
+}
+
+func (a *API) routeResource(w http.ResponseWriter, r *http.Request, rest []string, body []byte) {
+	if len(rest) < 2 || rest[0] != "serverchip" {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	// resource/serverchip/{uuid}/lock
+	if len(rest) == 3 && rest[2] == "lock" {
+		a.serverLock(w, r, rest[1], body)
+		return
+	}
+
+	// resource/serverchip/{uuid}/labels
+	if len(rest) == 3 && rest[2] == "labels" {
+		a.serverLabels(w, r, rest[1], body)
+		return
+	}
+
+	if rest[1] != "billing" {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	// resource/serverchip/billing
+	if len(rest) == 2 {
+		if r.Method != http.MethodPost {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		a.order(w, body)
+		return
+	}
+
+	billing, ok := a.billing[rest[2]]
+	if !ok {
+		writeError(w, http.StatusNotFound, "resource not found")
+		return
+	}
+
+	// resource/serverchip/billing/{uuid}/prolong
+	if len(rest) == 4 && rest[3] == "prolong" {
+		var prolong struct {
+			Periods int `json:"periods"`
+		}
+		if err := json.Unmarshal(body, &prolong); err != nil || prolong.Periods < 1 {
+			writeError(w, http.StatusBadRequest, "periods must be positive")
+			return
+		}
+		paidUntil := billing.PaidUntil.AddDate(0, prolong.Periods, 0)
+		billing.PaidUntil = &paidUntil
+		writeJSON(w, http.StatusOK, map[string]interface{}{"result": billing})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{"result": billing})
+	case http.MethodPatch:
+		var update struct {
+			Period      *string `json:"period"`
+			AutoRenewal *bool   `json:"auto_renewal"`
+		}
+		if err := json.Unmarshal(body, &update); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if update.Period != nil {
+			billing.Period = *update.Period
+		}
+		if update.AutoRenewal != nil {
+			billing.AutoRenewal = *update.AutoRenewal
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"result": billing})
+	case http.MethodDelete:
+		if a.locks[billing.UUID] {
+			writeError(w, http.StatusConflict, "server is locked")
+			return
+		}
+		if r.URL.Query().Get("cancel_mode") == "immediate" {
+			if server := a.findServerLocked(billing.UUID); server != nil {
+				delete(a.servers, server.ID)
+			}
+			delete(a.billing, billing.UUID)
+		} else {
+			billing.CancelAtPeriodEnd = true
+			billing.AutoRenewal = false
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (a *API) order(w http.ResponseWriter, body []byte) {
+	order := Order{}
+	if err := json.Unmarshal(body, &order); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	a.orders = append(a.orders, order)
+
+	if !a.hasServiceLocked(order["service_uuid"]) {
+		writeError(w, http.StatusBadRequest, "unknown service_uuid")
+		return
+	}
+	if !a.hasLocationLocked(order["location_uuid"]) {
+		writeError(w, http.StatusBadRequest, "unknown location_uuid")
+		return
+	}
+
+	name, _ := order["name"].(string)
+	quantity := 1
+	if q, ok := order["quantity"].(float64); ok && q > 1 {
+		quantity = int(q)
+	}
+
+	result := make([]map[string]interface{}, 0, quantity)
+	var task *Task
+	for i := 0; i < quantity; i++ {
+		// Like the real API, every server of the order gets the name it was
+		// ordered with.
+		server := a.orderServerLocked(order, name)
+		task = a.addTaskLocked(server.ID, "")
+		result = append(result, map[string]interface{}{
+			"uuid":   server.UUID,
+			"id":     strconv.Itoa(server.ID),
+			"name":   server.Name,
+			"status": server.Status,
+		})
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"task_id": strconv.Itoa(task.ID),
+		"result":  result,
+	})
+}
+
+func (a *API) hasServiceLocked(uuid interface{}) bool {
+	for _, service := range a.services {
+		if service.UUID == uuid {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (a *API) hasLocationLocked(uuid interface{}) bool {
+	for _, location := range a.locations {
+		if location.UUID == uuid {
+			return true
+		}
+	}
+
+	return false
+}
+
+// orderServerLocked creates one server of an order with its billing record.
+func (a *API) orderServerLocked(order Order, name string) *Server {
+	server := a.addServerLocked(Server{Name: name, Status: "active"})
+	server.Network = &Network{PrimaryIP: fmt.Sprintf("192.0.2.%d", server.ID%254+1)}
+	if labels, ok := order["labels"].(map[string]interface{}); ok {
+		server.Labels = make(map[string]string, len(labels))
+		for key, value := range labels {
+			server.Labels[key], _ = value.(string)
+		}
+	}
+
+	period, _ := order["period"].(string)
+	if period == "" {
+		period = "monthly"
+	}
+	autoRenewal, _ := order["auto_renewal"].(bool)
+	paidUntil := server.CreatedAt.AddDate(0, 1, 0)
+	a.billing[server.UUID] = &Billing{
+		UUID:        server.UUID,
+		Period:      period,
+		AutoRenewal: autoRenewal,
+		PaidUntil:   &paidUntil,
+	}
+
+	return server
+}
+
+func (a *API) serverLock(w http.ResponseWriter, r *http.Request, uuid string, body []byte) {
+	if _, ok := a.billing[uuid]; !ok {
+		writeError(w, http.StatusNotFound, "resource not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"result": map[string]bool{"locked": a.locks[uuid]},
+		})
+	case http.MethodPut:
+		var lock struct {
+			Locked bool `json:"locked"`
+		}
+		if err := json.Unmarshal(body, &lock); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		a.locks[uuid] = lock.Locked
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (a *API) serverLabels(w http.ResponseWriter, r *http.Request, uuid string, body []byte) {
+	server := a.findServerLocked(uuid)
+	if server == nil {
+		writeError(w, http.StatusNotFound, "resource not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		labels := server.Labels
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"result": map[string]interface{}{"labels": labels},
+		})
+	case http.MethodPut:
+		var update struct {
+			Labels map[string]string `json:"labels"`
+		}
+		if err := json.Unmarshal(body, &update); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		server.Labels = update.Labels
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (a *API) routeSSHKey(w http.ResponseWriter, r *http.Request, rest []string, body []byte) {
+	if len(rest) == 0 || rest[0] != "key" {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	if len(rest) == 1 {
+		switch r.Method {
+		case http.MethodGet:
+			keys := make([]*SSHKey, 0, len(a.sshKeys))
+			for _, key := range a.sshKeys {
+				keys = append(keys, key)
+			}
+			writeJSON(w, http.StatusOK, map[string]interface{}{"result": keys})
+		case http.MethodPost:
+			key := &SSHKey{}
+			if err := json.Unmarshal(body, key); err != nil || key.PublicKey == "" {
+				writeError(w, http.StatusBadRequest, "public_key is required")
+				return
+			}
+			a.nextID++
+			now := time.Now().UTC().Truncate(time.Second)
+			key.UUID = fakeUUID(a.nextID)
+			key.CreatedAt = &now
+			a.sshKeys[key.UUID] = key
+			writeJSON(w, http.StatusOK, map[string]interface{}{"result": key})
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+
+		return
+	}
+
+	key, ok := a.sshKeys[rest[1]]
+	if !ok {
+		writeError(w, http.StatusNotFound, "ssh key not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{"result": key})
+	case http.MethodPatch:
+		var update struct {
+			Name      *string `json:"name"`
+			PublicKey *string `json:"public_key"`
+		}
+		if err := json.Unmarshal(body, &update); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if update.Name != nil {
+			key.Name = *update.Name
+		}
+		if update.PublicKey != nil {
+			key.PublicKey = *update.PublicKey
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"result": key})
+	case http.MethodDelete:
+		delete(a.sshKeys, key.UUID)
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (a *API) routeCustomOS(w http.ResponseWriter, r *http.Request, rest []string, body []byte) {
+	if len(rest) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			templates := make([]*CustomOSTemplate, 0, len(a.customOS))
+			for _, t := range a.customOS {
+				templates = append(templates, t)
+			}
+			writeJSON(w, http.StatusOK, map[string]interface{}{"result": templates})
+		case http.MethodPost:
+			t := &CustomOSTemplate{}
+			if err := json.Unmarshal(body, t); err != nil || t.Name == "" || t.Content == "" {
+				writeError(w, http.StatusBadRequest, "name and content are required")
+				return
+			}
+			a.nextID++
+			now := time.Now().UTC().Truncate(time.Second)
+			t.UUID = fakeUUID(a.nextID)
+			t.CreatedAt = &now
+			a.customOS[t.UUID] = t
+			writeJSON(w, http.StatusOK, map[string]interface{}{"result": t})
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		}
+
+		return
+	}
+
+	t, ok := a.customOS[rest[0]]
+	if !ok {
+		writeError(w, http.StatusNotFound, "os template not found")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{"result": t})
+	case http.MethodPatch:
+		var update struct {
+			Name        *string `json:"name"`
+			Description *string `json:"description"`
+			Content     *string `json:"content"`
+		}
+		if err := json.Unmarshal(body, &update); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if update.Name != nil {
+			t.Name = *update.Name
+		}
+		if update.Description != nil {
+			t.Description = *update.Description
+		}
+		if update.Content != nil {
+			t.Content = *update.Content
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"result": t})
+	case http.MethodDelete:
+		delete(a.customOS, t.UUID)
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+func (a *API) filterAvailabilityLocked(r *http.Request) []*Availability {
+	serviceUUID := r.URL.Query().Get("service_uuid")
+	locationUUID := r.URL.Query().Get("location_uuid")
+
+	availability := make([]*Availability, 0, len(a.availability))
+	for _, av := range a.availability {
+		if serviceUUID != "" && av.ServiceUUID != serviceUUID {
+			continue
+		}
+		if locationUUID != "" && av.LocationUUID != locationUUID {
+			continue
+		}
+		availability = append(availability, av)
+	}
+
+	return availability
+}
@@ -5,7 +5,9 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/mutexkv"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/servers"
 )
 
 const (
@@ -56,6 +58,12 @@ const (
 	objectServerConfiguration = "server configuration"
 	objectServerLocation      = "server location"
 	objectServerOS            = "server operating system"
+	objectServerSSHKey        = "server ssh key"
+	objectServerHardware      = "server hardware"
+	objectServerTraffic       = "server traffic"
+	objectServerOSTemplate    = "server os template"
+	objectServerAvailability  = "server availability"
+	objectServerGroup         = "server group"
 )
 
 // This is a global MutexKV for use within this plugin.
@@ -79,27 +87,27 @@ func Provider() *schema.Provider {
 			},
 			"auth_url": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("OS_AUTH_URL", nil),
-				Description: "Base url to work with auth API (Keystone URL).",
+				Description: "Base url to work with auth API (Keystone URL). Required unless set in a shared credentials profile.",
 			},
 			"auth_region": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("OS_REGION_NAME", nil),
-				Description: "Region for Keystone and Resell API URLs.",
+				Description: "Region for Keystone and Resell API URLs. Required unless set in a shared credentials profile.",
 			},
 			"domain_name": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("OS_DOMAIN_NAME", nil),
-				Description: "Your domain name i.e. your account id",
+				Description: "Your domain name i.e. your account id. Required unless set in a shared credentials profile.",
 			},
 			"username": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("OS_USERNAME", nil),
-				Description: "Service user username",
+				Description: "Service user username. Required unless set in a shared credentials profile.",
 			},
 			"user_domain_name": {
 				Type:        schema.TypeString,
@@ -109,9 +117,22 @@ func Provider() *schema.Provider {
 			},
 			"password": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("OS_PASSWORD", nil),
-				Description: "Service user password",
+				Description: "Service user password. Required unless set in a shared credentials profile.",
+			},
+			"shared_credentials_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SEL_SHARED_CREDENTIALS_FILE", nil),
+				Description: "Path to the YAML file with named credentials profiles. Defaults to " + defaultSharedCredentialsFile + ".",
+			},
+			"profile": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SEL_PROFILE", nil),
+				Description: "Profile of the shared credentials file to use. Defaults to " + defaultSharedCredentialsProfile + ". " +
+					"Values set in the provider block or in environment variables take precedence over the profile.",
 			},
 			"servers_token": {
 				Type:        schema.TypeString,
@@ -120,6 +141,66 @@ func Provider() *schema.Provider {
 				Description: "Bearer token for dedicated servers API access. If not provided, will use Keystone authentication token.",
 				Sensitive:   true,
 			},
+			"servers_username": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SEL_SERVERS_USERNAME", nil),
+				Description: "Name of the IAM service user, e.g. created with selectel_iam_serviceuser_v1, the provider obtains and refreshes dedicated servers API tokens for. " +
+					"Replaces a long-lived servers_token. Defaults to the provider user.",
+			},
+			"servers_password": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SEL_SERVERS_PASSWORD", nil),
+				Description: "Password of the servers_username service user.",
+				Sensitive:   true,
+			},
+			"servers_project_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SEL_SERVERS_PROJECT_ID", nil),
+				Description: "Project the servers_username token is scoped to. Without it the token is scoped to the account.",
+			},
+			"default_labels": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ValidateFunc: validateServerLabels,
+				Description:  "Labels merged into every dedicated server managed by the provider.",
+			},
+			"servers_endpoint": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("SEL_SERVERS_ENDPOINT", servers.DefaultBaseURL),
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				Description:  "Base URL of the dedicated servers API, e.g. a staging gateway or a local stand-in.",
+			},
+			"http_proxy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("SEL_SERVERS_HTTP_PROXY", nil),
+				ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				Description:  "Proxy for dedicated servers API requests. Defaults to the HTTPS_PROXY and HTTP_PROXY environment variables.",
+			},
+			"ca_cert_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SEL_SERVERS_CA_CERT_FILE", nil),
+				Description: "PEM file with CA certificates trusted for the dedicated servers API in addition to the system ones.",
+			},
+			"insecure": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("SEL_SERVERS_INSECURE", false),
+				Description: "Skip TLS certificate verification of the dedicated servers API. Use for testing only.",
+			},
+			"request_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("SEL_SERVERS_REQUEST_TIMEOUT", int(servers.DefaultRequestTimeout.Seconds())),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Timeout of a single dedicated servers API request in seconds.",
+			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"selectel_domains_domain_v1":                dataSourceDomainsDomainV1(),
@@ -142,6 +223,10 @@ func Provider() *schema.Provider {
 			"selectel_dedicated_server_os_v1":             dataSourceDedicatedServerOSV1(),
 			"selectel_dedicated_server_services_v1":       dataSourceDedicatedServerServicesV1(),
 			"selectel_dedicated_server_tasks_v1":          dataSourceDedicatedServerTasksV1(),
+			"selectel_dedicated_server_hardware_v1":       dataSourceDedicatedServerHardwareV1(),
+			"selectel_dedicated_server_traffic_v1":        dataSourceDedicatedServerTrafficV1(),
+			"selectel_dedicated_os_template_v1":           dataSourceDedicatedOSTemplateV1(),
+			"selectel_dedicated_server_availability_v1":   dataSourceDedicatedServerAvailabilityV1(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"selectel_vpc_floatingip_v2":                            resourceVPCFloatingIPV2(),
@@ -184,7 +269,10 @@ func Provider() *schema.Provider {
 			"selectel_secretsmanager_secret_v1":                     resourceSecretsManagerSecretV1(),
 			"selectel_secretsmanager_certificate_v1":                resourceSecretsManagerCertificateV1(),
 			// Dedicated servers resources
-			"selectel_dedicated_server_v1": resourceDedicatedServerV1(),
+			"selectel_dedicated_server_v1":       resourceDedicatedServerV1(),
+			"selectel_dedicated_server_group_v1": resourceDedicatedServerGroupV1(),
+			"selectel_dedicated_ssh_key_v1":      resourceDedicatedSSHKeyV1(),
+			"selectel_dedicated_os_template_v1":  resourceDedicatedOSTemplateV1(),
 		},
 		ConfigureContextFunc: configureProvider,
 	}
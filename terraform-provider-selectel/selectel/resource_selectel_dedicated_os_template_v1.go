@@ -0,0 +1,196 @@
+package selectel
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/servers"
+)
+
+func resourceDedicatedOSTemplateV1() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDedicatedOSTemplateV1Create,
+		ReadContext:   resourceDedicatedOSTemplateV1Read,
+		UpdateContext: resourceDedicatedOSTemplateV1Update,
+		DeleteContext: resourceDedicatedOSTemplateV1Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringLenBetween(1, 255),
+				Description:  "Name of the install template",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description of the install template",
+			},
+			"based_on": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "Stock OS template the installation is based on, e.g. debian or ubuntu",
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Version of the base distribution, e.g. 12v2",
+			},
+			"arch": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "x86_64",
+				Description: "Architecture of the base distribution",
+			},
+			"install_type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					servers.OSTemplateInstallTypePreseed,
+					servers.OSTemplateInstallTypeKickstart,
+					servers.OSTemplateInstallTypeAutoinstall,
+				}, false),
+				Description: "Format of content: preseed, kickstart or autoinstall",
+			},
+			"content": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "Preseed, kickstart or autoinstall file content",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Install template creation timestamp",
+			},
+		},
+	}
+}
+
+func resourceDedicatedOSTemplateV1Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	serversService, err := config.GetServersService()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	createOpts := &servers.ServerOSTemplateCreate{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		BasedOn:     d.Get("based_on").(string),
+		Version:     d.Get("version").(string),
+		Arch:        d.Get("arch").(string),
+		InstallType: d.Get("install_type").(string),
+		Content:     d.Get("content").(string),
+	}
+
+	log.Print(msgCreate(objectServerOSTemplate, createOpts.Name))
+	template, err := serversService.CreateOSTemplate(ctx, createOpts)
+	if err != nil {
+		return diag.FromErr(errCreatingObject(objectServerOSTemplate, err))
+	}
+	if template == nil || template.UUID == "" {
+		return diag.FromErr(errReadFromResponse(objectServerOSTemplate))
+	}
+
+	d.SetId(template.UUID)
+
+	return resourceDedicatedOSTemplateV1Read(ctx, d, meta)
+}
+
+func resourceDedicatedOSTemplateV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	serversService, err := config.GetServersService()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Print(msgGet(objectServerOSTemplate, d.Id()))
+	template, err := serversService.GetOSTemplate(ctx, d.Id())
+	if err != nil {
+		if errors.Is(err, servers.ErrNotFound) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(errGettingObject(objectServerOSTemplate, d.Id(), err))
+	}
+
+	setServerOSTemplate(d, template)
+
+	return nil
+}
+
+func resourceDedicatedOSTemplateV1Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	serversService, err := config.GetServersService()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	updateOpts := &servers.ServerOSTemplateUpdate{}
+
+	if d.HasChange("name") {
+		name := d.Get("name").(string)
+		updateOpts.Name = &name
+	}
+
+	if d.HasChange("description") {
+		description := d.Get("description").(string)
+		updateOpts.Description = &description
+	}
+
+	if d.HasChange("content") {
+		content := d.Get("content").(string)
+		updateOpts.Content = &content
+	}
+
+	log.Print(msgUpdate(objectServerOSTemplate, d.Id(), d.Get("name")))
+	_, err = serversService.UpdateOSTemplate(ctx, d.Id(), updateOpts)
+	if err != nil {
+		return diag.FromErr(errUpdatingObject(objectServerOSTemplate, d.Id(), err))
+	}
+
+	return resourceDedicatedOSTemplateV1Read(ctx, d, meta)
+}
+
+func resourceDedicatedOSTemplateV1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	serversService, err := config.GetServersService()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Print(msgDelete(objectServerOSTemplate, d.Id()))
+	err = serversService.DeleteOSTemplate(ctx, d.Id())
+	if err != nil && !errors.Is(err, servers.ErrNotFound) {
+		return diag.FromErr(errDeletingObject(objectServerOSTemplate, d.Id(), err))
+	}
+
+	return nil
+}
+
+func setServerOSTemplate(d *schema.ResourceData, template *servers.ServerOSTemplate) {
+	d.Set("name", template.Name)
+	d.Set("description", template.Description)
+	d.Set("based_on", template.BasedOn)
+	d.Set("version", template.Version)
+	if template.Arch != "" {
+		d.Set("arch", template.Arch)
+	}
+	d.Set("install_type", template.InstallType)
+	d.Set("content", template.Content)
+	if template.CreatedAt != nil {
+		d.Set("created_at", template.CreatedAt.Format("2006-01-02T15:04:05Z"))
+	}
+}
@@ -0,0 +1,204 @@
+package selectel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/fakeservers"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/servers"
+)
+
+const (
+	testDedicatedOSTemplateV1Content        = "d-i partman-auto/method string regular\n"
+	testDedicatedOSTemplateV1UpdatedContent = "d-i partman-auto/method string lvm\n"
+)
+
+func TestAccDedicatedOSTemplateV1Basic(t *testing.T) {
+	templateName := acctest.RandomWithPrefix("tf-acc-os-template")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccSelectelDedicatedServersPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckDedicatedOSTemplateV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDedicatedOSTemplateV1Basic(templateName, testDedicatedOSTemplateV1Content),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("selectel_dedicated_os_template_v1.template_tf_acc_test_1", "created_at"),
+					resource.TestCheckResourceAttr("selectel_dedicated_os_template_v1.template_tf_acc_test_1", "name", templateName),
+					resource.TestCheckResourceAttr("selectel_dedicated_os_template_v1.template_tf_acc_test_1", "install_type", "preseed"),
+					resource.TestCheckResourceAttrPair(
+						"data.selectel_dedicated_os_template_v1.template_tf_acc_test_1", "template_id",
+						"selectel_dedicated_os_template_v1.template_tf_acc_test_1", "id",
+					),
+				),
+			},
+			{
+				Config: testAccDedicatedOSTemplateV1Basic(templateName, testDedicatedOSTemplateV1UpdatedContent),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("selectel_dedicated_os_template_v1.template_tf_acc_test_1", "content", testDedicatedOSTemplateV1UpdatedContent),
+				),
+			},
+			{
+				ResourceName:      "selectel_dedicated_os_template_v1.template_tf_acc_test_1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestUnitDedicatedOSTemplateV1Basic(t *testing.T) {
+	api := fakeservers.New()
+	defer api.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testServersFakePreCheck(t) },
+		ProviderFactories: testServersFakeProviderFactories(api),
+		Steps: []resource.TestStep{
+			{
+				Config: testServersFakeProviderBlock + testAccDedicatedOSTemplateV1Basic("debian-lvm", testDedicatedOSTemplateV1Content),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("selectel_dedicated_os_template_v1.template_tf_acc_test_1", "name", "debian-lvm"),
+					resource.TestCheckResourceAttr("data.selectel_dedicated_os_template_v1.template_tf_acc_test_1", "based_on", "debian"),
+				),
+			},
+		},
+	})
+}
+
+func TestDedicatedOSTemplateV1CRUDFake(t *testing.T) {
+	api := fakeservers.New()
+	defer api.Close()
+
+	ctx := context.Background()
+	meta := testServersFakeConfig(t, api)
+	d := schema.TestResourceDataRaw(t, resourceDedicatedOSTemplateV1().Schema, map[string]interface{}{
+		"name":         "debian-lvm",
+		"based_on":     "debian",
+		"version":      "12",
+		"install_type": servers.OSTemplateInstallTypePreseed,
+		"content":      testDedicatedOSTemplateV1Content,
+	})
+
+	diags := resourceDedicatedOSTemplateV1Create(ctx, d, meta)
+	require.False(t, diags.HasError(), diags)
+	require.NotEmpty(t, d.Id())
+	assert.Equal(t, "x86_64", d.Get("arch"))
+	assert.NotEmpty(t, d.Get("created_at"))
+
+	lookup := schema.TestResourceDataRaw(t, dataSourceDedicatedOSTemplateV1().Schema, map[string]interface{}{
+		"name": "debian-lvm",
+	})
+	diags = dataSourceDedicatedOSTemplateV1Read(ctx, lookup, meta)
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, d.Id(), lookup.Get("template_id"))
+	assert.Equal(t, testDedicatedOSTemplateV1Content, lookup.Get("content"))
+
+	server := schema.TestResourceDataRaw(t, resourceDedicatedServerV1().Schema, map[string]interface{}{
+		"name":           "db-1",
+		"location_id":    1,
+		"root_size":      20,
+		"os_template_id": d.Id(),
+	})
+	diags = resourceDedicatedServerV1Create(ctx, server, meta)
+	require.False(t, diags.HasError(), diags)
+
+	orders := api.Orders()
+	require.Len(t, orders, 1)
+	assert.Equal(t, d.Id(), orders[0]["custom_template_uuid"])
+	assert.Equal(t, "debian", orders[0]["os_template"])
+
+	diags = resourceDedicatedOSTemplateV1Delete(ctx, d, meta)
+	require.False(t, diags.HasError(), diags)
+
+	diags = resourceDedicatedOSTemplateV1Read(ctx, d, meta)
+	require.False(t, diags.HasError(), diags)
+	assert.Empty(t, d.Id())
+}
+
+func TestFindServerOSTemplate(t *testing.T) {
+	templates := []*servers.ServerOSTemplate{
+		{UUID: "1b0c1e43-9b2e-4f0e-8a3e-0d2f5f3a7c11", Name: "debian-lvm"},
+		{UUID: "7f1c8d9e-2a4b-4c6d-8e0f-1a2b3c4d5e6f", Name: "ubuntu"},
+		{UUID: "9a8b7c6d-5e4f-4a3b-2c1d-0e9f8a7b6c5d", Name: "ubuntu"},
+	}
+
+	template, err := findServerOSTemplate(templates, "debian-lvm")
+	assert.NoError(t, err)
+	assert.Equal(t, templates[0], template)
+
+	template, err = findServerOSTemplate(templates, "9a8b7c6d-5e4f-4a3b-2c1d-0e9f8a7b6c5d")
+	assert.NoError(t, err)
+	assert.Equal(t, templates[2], template)
+
+	_, err = findServerOSTemplate(templates, "ubuntu")
+	assert.Error(t, err)
+
+	_, err = findServerOSTemplate(templates, "missing")
+	assert.Error(t, err)
+}
+
+func TestFindServerOSTemplateByUUID(t *testing.T) {
+	templates := []*servers.ServerOSTemplate{
+		{UUID: "1b0c1e43-9b2e-4f0e-8a3e-0d2f5f3a7c11", Name: "debian-lvm"},
+		{UUID: "7f1c8d9e-2a4b-4c6d-8e0f-1a2b3c4d5e6f", Name: "9a8b7c6d-5e4f-4a3b-2c1d-0e9f8a7b6c5d"},
+	}
+
+	template, err := findServerOSTemplateByUUID(templates, "1b0c1e43-9b2e-4f0e-8a3e-0d2f5f3a7c11")
+	assert.NoError(t, err)
+	assert.Equal(t, templates[0], template)
+
+	_, err = findServerOSTemplateByUUID(templates, "debian-lvm")
+	assert.Error(t, err)
+
+	_, err = findServerOSTemplateByUUID(templates, "9a8b7c6d-5e4f-4a3b-2c1d-0e9f8a7b6c5d")
+	assert.Error(t, err)
+}
+
+func testAccCheckDedicatedOSTemplateV1Destroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+	serversService, err := config.GetServersService()
+	if err != nil {
+		return fmt.Errorf("can't get servers service for test: %w", err)
+	}
+
+	ctx := context.Background()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "selectel_dedicated_os_template_v1" {
+			continue
+		}
+
+		_, err := serversService.GetOSTemplate(ctx, rs.Primary.ID)
+		if err == nil {
+			return errors.New("server os template still exists")
+		}
+	}
+
+	return nil
+}
+
+func testAccDedicatedOSTemplateV1Basic(name, content string) string {
+	return fmt.Sprintf(`
+resource "selectel_dedicated_os_template_v1" "template_tf_acc_test_1" {
+  name         = "%s"
+  based_on     = "debian"
+  version      = "12"
+  install_type = "preseed"
+  content      = %q
+}
+
+data "selectel_dedicated_os_template_v1" "template_tf_acc_test_1" {
+  name = selectel_dedicated_os_template_v1.template_tf_acc_test_1.name
+}
+`, name, content)
+}
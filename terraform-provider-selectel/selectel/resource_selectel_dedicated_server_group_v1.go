@@ -0,0 +1,770 @@
+package selectel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/servers"
+)
+
+// dedicatedServerGroupV1SpecKeys — аргументы selectel_dedicated_server_v1,
+// общие для всех серверов группы. Изменение любого из них пересоздает группу
+var dedicatedServerGroupV1SpecKeys = []string{
+	"location_id", "config_id", "price_plan_uuid", "os_id", "os_template_id", "user_data",
+	"raid_type", "swap_size", "root_size", "custom_partitions", "raid_array", "volume_group",
+}
+
+// dedicatedServerGroupV1MutableKeys — аргументы аренды, которые применяются
+// ко всем серверам группы без пересоздания
+var dedicatedServerGroupV1MutableKeys = []string{
+	"labels", "labels_all", "billing_period", "auto_renewal", "cancel_mode", "wait_for_stock",
+}
+
+func resourceDedicatedServerGroupV1() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDedicatedServerGroupV1Create,
+		ReadContext:   resourceDedicatedServerGroupV1Read,
+		UpdateContext: resourceDedicatedServerGroupV1Update,
+		DeleteContext: resourceDedicatedServerGroupV1Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDedicatedServerGroupV1ImportState,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+		CustomizeDiff: customdiff.All(
+			dedicatedServerV1DiskLayoutCustomizeDiff,
+			dedicatedServerGroupV1MembersCustomizeDiff,
+			dedicatedServerV1LabelsCustomizeDiff,
+		),
+		Schema: dedicatedServerGroupV1Schema(),
+	}
+}
+
+func dedicatedServerGroupV1Schema() map[string]*schema.Schema {
+	groupSchema := map[string]*schema.Schema{
+		"name": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringLenBetween(1, 255),
+			Description:  "Name of the group. It is the hostname of every ordered server, and servers are renamed to <name>-<n>, numbered in order",
+		},
+		"quantity": {
+			Type:         schema.TypeInt,
+			Required:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+			Description:  "Number of servers in the group. Increasing it orders more servers, decreasing it requires naming the servers in remove_members",
+		},
+		"remove_members": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "UUIDs of members to cancel, or names that match a single member. Members listed here while quantity is unchanged are replaced with new servers",
+		},
+		"members": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"uuid": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"id": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"name": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"status": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"primary_ip": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"additional_ips": {
+						Type:     schema.TypeList,
+						Computed: true,
+						Elem:     &schema.Schema{Type: schema.TypeString},
+					},
+				},
+			},
+			Description: "Servers of the group in the order they were ordered",
+		},
+	}
+
+	// Общую спецификацию берем из selectel_dedicated_server_v1, чтобы группа
+	// и одиночный сервер принимали одинаковую разметку дисков и ОС
+	serverSchema := resourceDedicatedServerV1().Schema
+	for _, key := range dedicatedServerGroupV1SpecKeys {
+		spec := *serverSchema[key]
+		spec.ForceNew = true
+		spec.DiffSuppressFunc = dedicatedServerGroupV1ImportedSpecDiffSuppress
+		groupSchema[key] = &spec
+	}
+	for _, key := range dedicatedServerGroupV1MutableKeys {
+		spec := *serverSchema[key]
+		groupSchema[key] = &spec
+	}
+	// Ключи доступа записываются только при установке ОС, поэтому их
+	// изменение применяется к дозаказанным серверам
+	for _, key := range dedicatedServerV1InstallOnlyKeys {
+		spec := *serverSchema[key]
+		spec.Description += ". New keys apply to servers ordered after the change"
+		spec.DiffSuppressFunc = dedicatedServerGroupV1ImportedSpecDiffSuppress
+		groupSchema[key] = &spec
+	}
+	groupSchema["labels"].Description = "Key/value labels for every server of the group, merged with the provider default_labels"
+	groupSchema["labels_all"].Description = "All labels of every server of the group including the provider default_labels"
+
+	return groupSchema
+}
+
+// dedicatedServerGroupV1ImportedSpecDiffSuppress скрывает в плане
+// спецификацию импортированной группы. Ее нельзя прочитать с серверов, и в
+// состоянии она пуста (root_size обязателен и у созданной группы не равен
+// нулю), поэтому она берется из конфигурации при первом изменении группы
+func dedicatedServerGroupV1ImportedSpecDiffSuppress(_, _, _ string, d *schema.ResourceData) bool {
+	rootSize, _ := d.GetChange("root_size")
+
+	return d.Id() != "" && rootSize.(int) == 0
+}
+
+// dedicatedServerGroupV1AdoptSpec записывает спецификацию импортированной
+// группы из конфигурации, чтобы дозаказать серверы с ней
+func dedicatedServerGroupV1AdoptSpec(ctx context.Context, d *schema.ResourceData) error {
+	if d.Get("root_size").(int) != 0 {
+		return nil
+	}
+
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsWhollyKnown() {
+		return errors.New("the server specification of the imported group is not known")
+	}
+
+	groupSchema := schema.InternalMap(dedicatedServerGroupV1Schema())
+	diff, err := groupSchema.Diff(ctx, nil, terraform.NewResourceConfigShimmed(rawConfig, groupSchema.CoreConfigSchema()), nil, nil, false)
+	if err != nil {
+		return err
+	}
+	configured, err := groupSchema.Data(nil, diff)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range append(slices.Clone(dedicatedServerGroupV1SpecKeys), dedicatedServerV1InstallOnlyKeys...) {
+		if err := d.Set(key, configured.Get(key)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// dedicatedServerGroupV1Member — сервер группы в состоянии Terraform
+type dedicatedServerGroupV1Member struct {
+	UUID          string
+	ID            string
+	Name          string
+	Status        string
+	PrimaryIP     string
+	AdditionalIPs []string
+}
+
+// ServerID возвращает числовой ID сервера или 0, пока он неизвестен
+func (m dedicatedServerGroupV1Member) ServerID() int {
+	id, _ := strconv.Atoi(m.ID)
+
+	return id
+}
+
+func expandDedicatedServerGroupV1Members(v interface{}) []dedicatedServerGroupV1Member {
+	rawMembers, _ := v.([]interface{})
+	members := make([]dedicatedServerGroupV1Member, 0, len(rawMembers))
+	for _, rawMember := range rawMembers {
+		m := rawMember.(map[string]interface{})
+		member := dedicatedServerGroupV1Member{
+			UUID:      m["uuid"].(string),
+			ID:        m["id"].(string),
+			Name:      m["name"].(string),
+			Status:    m["status"].(string),
+			PrimaryIP: m["primary_ip"].(string),
+		}
+		for _, ip := range m["additional_ips"].([]interface{}) {
+			member.AdditionalIPs = append(member.AdditionalIPs, ip.(string))
+		}
+		members = append(members, member)
+	}
+
+	return members
+}
+
+func flattenDedicatedServerGroupV1Members(members []dedicatedServerGroupV1Member) []interface{} {
+	result := make([]interface{}, len(members))
+	for i, member := range members {
+		result[i] = map[string]interface{}{
+			"uuid":           member.UUID,
+			"id":             member.ID,
+			"name":           member.Name,
+			"status":         member.Status,
+			"primary_ip":     member.PrimaryIP,
+			"additional_ips": member.AdditionalIPs,
+		}
+	}
+
+	return result
+}
+
+// splitDedicatedServerGroupV1Members делит серверы на оставшиеся в группе и
+// перечисленные в remove_members по UUID или имени. Серверы могут быть
+// переименованы вне Terraform, поэтому имя, общее для нескольких серверов,
+// считается ошибкой, а не указанием отменить их все
+func splitDedicatedServerGroupV1Members(members []dedicatedServerGroupV1Member, removeRefs *schema.Set,
+) (kept, removed []dedicatedServerGroupV1Member, err error) {
+	if removeRefs != nil {
+		names := make(map[string]int, len(members))
+		for _, member := range members {
+			names[member.Name]++
+		}
+		for _, ref := range removeRefs.List() {
+			if count := names[ref.(string)]; count > 1 {
+				return nil, nil, fmt.Errorf("%d servers of the group are named %q: list them in remove_members by UUID", count, ref)
+			}
+		}
+	}
+
+	for _, member := range members {
+		if removeRefs != nil && (removeRefs.Contains(member.UUID) || removeRefs.Contains(member.Name)) {
+			removed = append(removed, member)
+			continue
+		}
+		kept = append(kept, member)
+	}
+
+	return kept, removed, nil
+}
+
+// dedicatedServerGroupV1MembersCustomizeDiff планирует изменение состава
+// группы: отказывает в уменьшении quantity без remove_members и возвращает
+// в план серверы, отмененные вне Terraform
+func dedicatedServerGroupV1MembersCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.NewValueKnown("quantity") || !d.NewValueKnown("remove_members") {
+		return nil
+	}
+
+	quantity := d.Get("quantity").(int)
+	members := expandDedicatedServerGroupV1Members(d.Get("members"))
+	removeRefs, _ := d.Get("remove_members").(*schema.Set)
+	kept, removed, err := splitDedicatedServerGroupV1Members(members, removeRefs)
+	if err != nil {
+		return err
+	}
+
+	if len(kept) > quantity {
+		return fmt.Errorf("quantity %d is less than the %d servers left in the group: "+
+			"list %d of them in remove_members by UUID", quantity, len(kept), len(kept)-quantity)
+	}
+
+	if len(removed) > 0 || len(kept) != quantity {
+		return d.SetNewComputed("members")
+	}
+
+	return nil
+}
+
+func resourceDedicatedServerGroupV1Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	createDeadline := time.Now().Add(d.Timeout(schema.TimeoutCreate))
+	config := meta.(*Config)
+	serversService, err := config.GetServersService()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	quantity := d.Get("quantity").(int)
+	members, err := orderDedicatedServerGroupV1Members(ctx, d, config, serversService, nil, quantity, createDeadline)
+	if len(members) > 0 {
+		d.SetId(resource.PrefixedUniqueId("server-group-"))
+		d.Set("members", flattenDedicatedServerGroupV1Members(members))
+	}
+	if err != nil {
+		return diag.FromErr(errCreatingObject(objectServerGroup, err))
+	}
+
+	log.Printf("[DEBUG] Created %s %s with %d servers", objectServerGroup, d.Id(), len(members))
+
+	return resourceDedicatedServerGroupV1Read(ctx, d, meta)
+}
+
+func resourceDedicatedServerGroupV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	serversService, err := config.GetServersService()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Print(msgGet(objectServerGroup, d.Id()))
+
+	members := expandDedicatedServerGroupV1Members(d.Get("members"))
+	if err := resolveDedicatedServerGroupV1MemberIDs(ctx, serversService, members); err != nil {
+		return diag.FromErr(errGettingObject(objectServerGroup, d.Id(), err))
+	}
+
+	var diags diag.Diagnostics
+	settings := expandDedicatedServerGroupV1Settings(d)
+	// labels_all расходится с ожидаемым, только если метки сервера
+	// отличаются, например после изменения default_labels
+	if err := d.Set("labels_all", mergeServerLabels(config.DefaultLabels, settings.Labels)); err != nil {
+		return diag.FromErr(err)
+	}
+	refreshed := make([]dedicatedServerGroupV1Member, 0, len(members))
+	for _, member := range members {
+		billing, err := serversService.GetServerBilling(ctx, member.UUID)
+		if err != nil && !errors.Is(err, servers.ErrNotFound) {
+			return diag.FromErr(errGettingObject(objectServerGroup, d.Id(), err))
+		}
+		gone := err != nil || billing == nil || isServerGone(billing.Status)
+
+		var server *servers.DedicatedServer
+		if !gone && member.ID != "" {
+			serverID, err := strconv.Atoi(member.ID)
+			if err != nil {
+				return diag.FromErr(errParseID(objectDedicatedServer, member.ID))
+			}
+
+			server, err = serversService.GetServer(ctx, serverID)
+			if err != nil && !errors.Is(err, servers.ErrNotFound) {
+				return diag.FromErr(errGettingObject(objectServerGroup, d.Id(), err))
+			}
+			gone = err != nil || isServerGone(server.Status)
+		}
+
+		// Серверы, отмененные вне Terraform, убираем из группы: план
+		// предложит дозаказать их до quantity
+		if gone {
+			log.Printf("[WARN] %s %s of %s %s is gone, removing it from the group", objectDedicatedServer, member.UUID, objectServerGroup, d.Id())
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("%s %s of %s %s is gone", objectDedicatedServer, member.Name, objectServerGroup, d.Id()),
+				Detail:   "The server was removed outside of Terraform and has been removed from the group. It will be re-ordered on the next apply.",
+			})
+			continue
+		}
+
+		// Числовой ID появляется у сервера не сразу после заказа: такой
+		// сервер остается в группе как есть до следующего чтения
+		if server == nil {
+			log.Printf("[DEBUG] Numeric ID of %s %s of %s %s is not known yet", objectDedicatedServer, member.UUID, objectServerGroup, d.Id())
+		} else {
+			// Сервер с именем группы еще ждет переименования в <name>-<n>
+			if server.Name != d.Get("name").(string) || member.Name == "" {
+				member.Name = server.Name
+			}
+			member.Status = server.Status
+			member.PrimaryIP = ""
+			member.AdditionalIPs = nil
+			if server.Network != nil {
+				member.PrimaryIP = server.Network.PrimaryIP
+				member.AdditionalIPs = server.Network.AdditionalIPs
+			}
+		}
+
+		if err := dedicatedServerGroupV1ReadMemberSettings(ctx, d, serversService, config.DefaultLabels, settings, member.UUID, billing); err != nil {
+			return diag.FromErr(errGettingObject(objectServerGroup, d.Id(), err))
+		}
+
+		refreshed = append(refreshed, member)
+	}
+
+	if err := d.Set("members", flattenDedicatedServerGroupV1Members(refreshed)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceDedicatedServerGroupV1Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	updateDeadline := time.Now().Add(d.Timeout(schema.TimeoutUpdate))
+	config := meta.(*Config)
+	serversService, err := config.GetServersService()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := dedicatedServerGroupV1AdoptSpec(ctx, d); err != nil {
+		return diag.FromErr(errUpdatingObject(objectServerGroup, d.Id(), err))
+	}
+
+	// В плане members вычисляемый, поэтому состав группы берем из состояния
+	oldMembers, _ := d.GetChange("members")
+	removeRefs, _ := d.Get("remove_members").(*schema.Set)
+	kept, removed, err := splitDedicatedServerGroupV1Members(expandDedicatedServerGroupV1Members(oldMembers), removeRefs)
+	if err != nil {
+		return diag.FromErr(errUpdatingObject(objectServerGroup, d.Id(), err))
+	}
+
+	// Сначала отменяем перечисленные серверы: после частичной ошибки в
+	// состоянии остаются только еще не отмененные
+	cancelMode := d.Get("cancel_mode").(string)
+	for i, member := range removed {
+		log.Printf("[DEBUG] Cancelling rental of %s %s of %s %s (%s)", objectDedicatedServer, member.UUID, objectServerGroup, d.Id(), cancelMode)
+		unlock := lockDedicatedServer(member.UUID, member.ID)
+		err := dedicatedServerRetryOnConflict(ctx, serversService, member.ServerID(), d.Timeout(schema.TimeoutUpdate), func() error {
+			return serversService.CancelServerResource(ctx, member.UUID, &servers.ServerCancelOpts{Mode: cancelMode})
+		})
+		unlock()
+		if err != nil && !errors.Is(err, servers.ErrNotFound) {
+			d.Set("members", flattenDedicatedServerGroupV1Members(append(kept, removed[i:]...)))
+			return diag.FromErr(errUpdatingObject(objectServerGroup, d.Id(), err))
+		}
+	}
+	if err := d.Set("members", flattenDedicatedServerGroupV1Members(kept)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	quantity := d.Get("quantity").(int)
+	if len(kept) > quantity {
+		return diag.FromErr(errUpdatingObject(objectServerGroup, d.Id(),
+			fmt.Errorf("%d servers are left in the group, but quantity is %d: list the extra servers in remove_members", len(kept), quantity)))
+	}
+
+	// Аренду оставшихся серверов меняем до дозаказа: новые серверы сразу
+	// заказываются с актуальными параметрами
+	if d.HasChanges("billing_period", "auto_renewal") {
+		updateOpts := &servers.ServerBillingUpdate{}
+		if d.HasChange("billing_period") {
+			period := d.Get("billing_period").(string)
+			updateOpts.Period = &period
+		}
+		if d.HasChange("auto_renewal") {
+			autoRenewal := d.Get("auto_renewal").(bool)
+			updateOpts.AutoRenewal = &autoRenewal
+		}
+
+		for _, member := range kept {
+			log.Print(msgUpdate(objectDedicatedServer, member.UUID, updateOpts))
+			unlock := lockDedicatedServer(member.UUID, member.ID)
+			err := dedicatedServerRetryOnConflict(ctx, serversService, member.ServerID(), d.Timeout(schema.TimeoutUpdate), func() error {
+				_, err := serversService.UpdateServerBilling(ctx, member.UUID, updateOpts)
+				return err
+			})
+			unlock()
+			if err != nil {
+				return diag.FromErr(errUpdatingObject(objectServerGroup, d.Id(), err))
+			}
+		}
+	}
+
+	if d.HasChanges("labels", "labels_all") {
+		labels := mergeServerLabels(config.DefaultLabels, expandServerLabels(d.Get("labels")))
+		for _, member := range kept {
+			unlock := lockDedicatedServer(member.UUID, member.ID)
+			err := dedicatedServerRetryOnConflict(ctx, serversService, member.ServerID(), d.Timeout(schema.TimeoutUpdate), func() error {
+				return serversService.SetServerLabels(ctx, member.UUID, &servers.ServerLabels{Labels: labels})
+			})
+			unlock()
+			if err != nil {
+				return diag.FromErr(errUpdatingObject(objectServerGroup, d.Id(), err))
+			}
+		}
+	}
+
+	if err := renameDedicatedServerGroupV1Members(ctx, serversService, d.Get("name").(string), kept, time.Until(updateDeadline)); err != nil {
+		return diag.FromErr(errUpdatingObject(objectServerGroup, d.Id(), err))
+	}
+
+	if missing := quantity - len(kept); missing > 0 {
+		ordered, err := orderDedicatedServerGroupV1Members(ctx, d, config, serversService, kept, missing, updateDeadline)
+		d.Set("members", flattenDedicatedServerGroupV1Members(append(kept, ordered...)))
+		if err != nil {
+			return diag.FromErr(errUpdatingObject(objectServerGroup, d.Id(), err))
+		}
+	}
+
+	return resourceDedicatedServerGroupV1Read(ctx, d, meta)
+}
+
+func resourceDedicatedServerGroupV1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	serversService, err := config.GetServersService()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	cancelMode := d.Get("cancel_mode").(string)
+	members := expandDedicatedServerGroupV1Members(d.Get("members"))
+	for i, member := range members {
+		log.Printf("[DEBUG] Cancelling rental of %s %s of %s %s (%s)", objectDedicatedServer, member.UUID, objectServerGroup, d.Id(), cancelMode)
+		unlock := lockDedicatedServer(member.UUID, member.ID)
+		err := dedicatedServerRetryOnConflict(ctx, serversService, member.ServerID(), d.Timeout(schema.TimeoutDelete), func() error {
+			return serversService.CancelServerResource(ctx, member.UUID, &servers.ServerCancelOpts{Mode: cancelMode})
+		})
+		unlock()
+		if err != nil && !errors.Is(err, servers.ErrNotFound) {
+			d.Set("members", flattenDedicatedServerGroupV1Members(members[i:]))
+			return diag.FromErr(errDeletingObject(objectServerGroup, d.Id(), err))
+		}
+	}
+
+	return nil
+}
+
+func resourceDedicatedServerGroupV1ImportState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	config := meta.(*Config)
+	serversService, err := config.GetServersService()
+	if err != nil {
+		return nil, err
+	}
+
+	uuids := strings.Split(d.Id(), ",")
+	members := make([]dedicatedServerGroupV1Member, 0, len(uuids))
+	for _, uuid := range uuids {
+		uuid = strings.TrimSpace(uuid)
+		if uuid == "" {
+			return nil, fmt.Errorf("invalid import format, expected: <server_uuid>[,<server_uuid>...]")
+		}
+		members = append(members, dedicatedServerGroupV1Member{UUID: uuid})
+	}
+
+	if err := resolveDedicatedServerGroupV1MemberIDs(ctx, serversService, members); err != nil {
+		return nil, err
+	}
+
+	// Имя группы — имя ее серверов без номера <name>-<n>
+	for _, member := range members {
+		if member.Name != "" {
+			d.Set("name", dedicatedServerGroupV1NameFromMember(member.Name))
+			break
+		}
+	}
+
+	// Аргументы аренды по умолчанию, как у созданной группы; спецификация
+	// остается пустой до первого изменения группы
+	groupSchema := dedicatedServerGroupV1Schema()
+	for _, key := range dedicatedServerGroupV1MutableKeys {
+		if defaultValue := groupSchema[key].Default; defaultValue != nil {
+			d.Set(key, defaultValue)
+		}
+	}
+
+	d.SetId(resource.PrefixedUniqueId("server-group-"))
+	d.Set("quantity", len(members))
+	d.Set("members", flattenDedicatedServerGroupV1Members(members))
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// resolveDedicatedServerGroupV1MemberIDs заполняет числовые ID и имена
+// серверов, которых еще не было при заказе, по списку серверов
+func resolveDedicatedServerGroupV1MemberIDs(ctx context.Context, serversService servers.ServersAPI, members []dedicatedServerGroupV1Member) error {
+	missing := false
+	for _, member := range members {
+		if member.ID == "" {
+			missing = true
+			break
+		}
+	}
+	if !missing {
+		return nil
+	}
+
+	byUUID, err := listDedicatedServersByUUID(ctx, serversService)
+	if err != nil {
+		return err
+	}
+
+	for i, member := range members {
+		if server, ok := byUUID[member.UUID]; ok && member.ID == "" && server.ID != 0 {
+			members[i].ID = strconv.Itoa(server.ID)
+			if members[i].Name == "" {
+				members[i].Name = server.Name
+			}
+		}
+	}
+
+	return nil
+}
+
+// dedicatedServerGroupV1Settings — аргументы аренды и метки, общие для всех
+// серверов группы
+type dedicatedServerGroupV1Settings struct {
+	BillingPeriod string
+	AutoRenewal   bool
+	Labels        map[string]string
+}
+
+func expandDedicatedServerGroupV1Settings(d *schema.ResourceData) dedicatedServerGroupV1Settings {
+	return dedicatedServerGroupV1Settings{
+		BillingPeriod: d.Get("billing_period").(string),
+		AutoRenewal:   d.Get("auto_renewal").(bool),
+		Labels:        expandServerLabels(d.Get("labels")),
+	}
+}
+
+// dedicatedServerGroupV1ReadMemberSettings сравнивает аренду и метки сервера
+// группы с аргументами группы. Расхождение записывается в состояние, чтобы
+// план применил аргументы ко всем серверам заново
+func dedicatedServerGroupV1ReadMemberSettings(ctx context.Context, d *schema.ResourceData, serversService servers.ServersAPI,
+	defaultLabels map[string]string, settings dedicatedServerGroupV1Settings, memberUUID string, billing *servers.ServerBilling,
+) error {
+	if billing.Period != "" && billing.Period != settings.BillingPeriod {
+		d.Set("billing_period", billing.Period)
+	}
+	if billing.AutoRenewal != settings.AutoRenewal {
+		d.Set("auto_renewal", billing.AutoRenewal)
+	}
+
+	labels, err := serversService.GetServerLabels(ctx, memberUUID)
+	if err != nil {
+		return err
+	}
+	if labels == nil {
+		return nil
+	}
+
+	actual := serverLabelsWithoutDefaults(labels.Labels, defaultLabels, settings.Labels)
+	if !maps.Equal(actual, settings.Labels) {
+		d.Set("labels", actual)
+	}
+	if !maps.Equal(labels.Labels, mergeServerLabels(defaultLabels, settings.Labels)) {
+		d.Set("labels_all", labels.Labels)
+	}
+
+	return nil
+}
+
+// orderDedicatedServerGroupV1Members заказывает quantity серверов группы
+// одним заказом через биллинг. Заказ дает всем серверам имя группы, поэтому
+// затем они переименовываются в <name>-<n>, чтобы их можно было указать в
+// remove_members по имени. Номера продолжают номера серверов existing
+func orderDedicatedServerGroupV1Members(ctx context.Context, d *schema.ResourceData, config *Config, serversService servers.ServersAPI,
+	existing []dedicatedServerGroupV1Member, quantity int, deadline time.Time,
+) ([]dedicatedServerGroupV1Member, error) {
+	sshKeys, err := expandDedicatedServerSSHKeys(ctx, d, serversService)
+	if err != nil {
+		return nil, err
+	}
+
+	name := d.Get("name").(string)
+	billingOpts, err := expandDedicatedServerBillingOpts(ctx, d, config, serversService, name, sshKeys)
+	if err != nil {
+		return nil, err
+	}
+	billingOpts.Quantity = quantity
+
+	log.Printf("[DEBUG] Ordering %d servers for %s %s", quantity, objectServerGroup, name)
+
+	stockRequest := serverStockRequest{
+		ServiceUUID:  billingOpts.ServiceUUID,
+		ConfigID:     d.Get("config_id").(int),
+		LocationUUID: billingOpts.LocationUUID,
+	}
+	response, err := placeDedicatedServerOrder(ctx, serversService, billingOpts, stockRequest, d.Get("wait_for_stock").(bool), deadline)
+	if err != nil {
+		return nil, err
+	}
+
+	index := nextDedicatedServerGroupV1MemberIndex(name, existing)
+	members := make([]dedicatedServerGroupV1Member, len(response.Result))
+	for i, result := range response.Result {
+		members[i] = dedicatedServerGroupV1Member{
+			UUID:   result.UUID,
+			ID:     result.ID,
+			Name:   fmt.Sprintf("%s-%d", name, index+i),
+			Status: result.Status,
+		}
+	}
+
+	// Возвращаем полученные серверы вместе с ошибкой, чтобы они попали в
+	// состояние и не остались в аренде без Terraform
+	if len(members) != quantity {
+		return members, fmt.Errorf("ordered %d servers, but the order returned %d", quantity, len(members))
+	}
+
+	return members, renameDedicatedServerGroupV1Members(ctx, serversService, name, members, time.Until(deadline))
+}
+
+// renameDedicatedServerGroupV1Members дает серверам группы, которые еще
+// называются именем группы, их имена <name>-<n> из состояния. Сервер без
+// числового ID переименовывается при следующем изменении группы
+func renameDedicatedServerGroupV1Members(ctx context.Context, serversService servers.ServersAPI, groupName string,
+	members []dedicatedServerGroupV1Member, timeout time.Duration,
+) error {
+	for _, member := range members {
+		serverID := member.ServerID()
+		if serverID == 0 || member.Name == groupName {
+			continue
+		}
+
+		server, err := serversService.GetServer(ctx, serverID)
+		if err != nil {
+			return err
+		}
+		if server.Name != groupName {
+			continue
+		}
+
+		log.Printf("[DEBUG] Renaming %s %d of %s %s to %s", objectDedicatedServer, serverID, objectServerGroup, groupName, member.Name)
+		unlock := lockDedicatedServer(member.UUID, member.ID)
+		err = dedicatedServerRetryOnConflict(ctx, serversService, serverID, timeout, func() error {
+			_, err := serversService.UpdateServer(ctx, serverID, &servers.DedicatedServerUpdate{Name: &member.Name})
+			return err
+		})
+		unlock()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// dedicatedServerGroupV1NameFromMember возвращает имя группы по имени ее
+// сервера <name>-<n>
+func dedicatedServerGroupV1NameFromMember(memberName string) string {
+	i := strings.LastIndex(memberName, "-")
+	if i <= 0 {
+		return memberName
+	}
+	if _, err := strconv.Atoi(memberName[i+1:]); err != nil {
+		return memberName
+	}
+
+	return memberName[:i]
+}
+
+// nextDedicatedServerGroupV1MemberIndex возвращает номер следующего сервера
+// группы name: на единицу больше наибольшего номера среди members
+func nextDedicatedServerGroupV1MemberIndex(name string, members []dedicatedServerGroupV1Member) int {
+	next := 1
+	for _, member := range members {
+		suffix, ok := strings.CutPrefix(member.Name, name+"-")
+		if !ok {
+			continue
+		}
+		if n, err := strconv.Atoi(suffix); err == nil && n >= next {
+			next = n + 1
+		}
+	}
+
+	return next
+}
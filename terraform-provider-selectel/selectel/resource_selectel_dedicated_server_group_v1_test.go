@@ -0,0 +1,473 @@
+package selectel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/fakeservers"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/servers"
+)
+
+func TestAccDedicatedServerGroupV1Basic(t *testing.T) {
+	groupName := acctest.RandomWithPrefix("tf-acc-group")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccSelectelDedicatedServersPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckDedicatedServerGroupV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDedicatedServerGroupV1Basic(groupName, 2, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("selectel_dedicated_server_group_v1.group_tf_acc_test_1", "members.#", "2"),
+					resource.TestCheckResourceAttrSet("selectel_dedicated_server_group_v1.group_tf_acc_test_1", "members.0.uuid"),
+					resource.TestCheckResourceAttrSet("selectel_dedicated_server_group_v1.group_tf_acc_test_1", "members.1.uuid"),
+				),
+			},
+			{
+				Config: testAccDedicatedServerGroupV1Basic(groupName, 3, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("selectel_dedicated_server_group_v1.group_tf_acc_test_1", "members.#", "3"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitDedicatedServerGroupV1Basic(t *testing.T) {
+	api := fakeservers.New()
+	defer api.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testServersFakePreCheck(t) },
+		ProviderFactories: testServersFakeProviderFactories(api),
+		Steps: []resource.TestStep{
+			{
+				Config: testServersFakeProviderBlock + testAccDedicatedServerGroupV1Basic("web", 3, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("selectel_dedicated_server_group_v1.group_tf_acc_test_1", "members.#", "3"),
+					resource.TestCheckResourceAttrSet("selectel_dedicated_server_group_v1.group_tf_acc_test_1", "members.2.primary_ip"),
+				),
+			},
+			{
+				Config: testServersFakeProviderBlock + testAccDedicatedServerGroupV1Basic("web", 4, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("selectel_dedicated_server_group_v1.group_tf_acc_test_1", "members.#", "4"),
+					resource.TestCheckResourceAttr("selectel_dedicated_server_group_v1.group_tf_acc_test_1", "members.3.status", "active"),
+				),
+			},
+		},
+	})
+}
+
+// testDedicatedServerGroupV1Update планирует новую конфигурацию группы и
+// применяет ее так же, как Terraform
+func testDedicatedServerGroupV1Update(ctx context.Context, t *testing.T, d *schema.ResourceData, meta interface{}, raw map[string]interface{},
+) (*schema.ResourceData, error) {
+	_, next, err := testServersFakePlan(ctx, t, resourceDedicatedServerGroupV1(), d, meta, raw)
+	if err != nil {
+		return nil, err
+	}
+
+	diags := resourceDedicatedServerGroupV1Update(ctx, next, meta)
+	require.False(t, diags.HasError(), diags)
+
+	return next, nil
+}
+
+func testDedicatedServerGroupV1Config(quantity int, removeMembers ...interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"name":           "web",
+		"quantity":       quantity,
+		"location_id":    1,
+		"root_size":      20,
+		"remove_members": removeMembers,
+	}
+}
+
+func TestDedicatedServerGroupV1ScaleFake(t *testing.T) {
+	api := fakeservers.New()
+	defer api.Close()
+
+	ctx := context.Background()
+	meta := testServersFakeConfig(t, api)
+	d := schema.TestResourceDataRaw(t, resourceDedicatedServerGroupV1().Schema, testDedicatedServerGroupV1Config(3))
+
+	diags := resourceDedicatedServerGroupV1Create(ctx, d, meta)
+	require.False(t, diags.HasError(), diags)
+	require.NotEmpty(t, d.Id())
+
+	// Серверы заказываются одним заказом и затем получают свои имена
+	orders := api.Orders()
+	require.Len(t, orders, 1)
+	assert.Equal(t, float64(3), orders[0]["quantity"])
+
+	members := expandDedicatedServerGroupV1Members(d.Get("members"))
+	require.Len(t, members, 3)
+	for i, member := range members {
+		server, ok := api.Server(member.UUID)
+		require.True(t, ok)
+		assert.Equal(t, fmt.Sprintf("web-%d", i+1), server.Name)
+		assert.Equal(t, server.Name, member.Name)
+		assert.Equal(t, strconv.Itoa(server.ID), member.ID)
+		assert.Equal(t, server.Network.PrimaryIP, member.PrimaryIP)
+		assert.Equal(t, "active", member.Status)
+	}
+
+	// Увеличение quantity дозаказывает недостающие серверы со следующими
+	// номерами
+	d, err := testDedicatedServerGroupV1Update(ctx, t, d, meta, testDedicatedServerGroupV1Config(5))
+	require.NoError(t, err)
+
+	orders = api.Orders()
+	require.Len(t, orders, 2)
+	assert.Equal(t, float64(2), orders[1]["quantity"])
+	require.Len(t, d.Get("members").([]interface{}), 5)
+	assert.Equal(t, members, expandDedicatedServerGroupV1Members(d.Get("members"))[:3])
+	for i, member := range expandDedicatedServerGroupV1Members(d.Get("members")) {
+		assert.Equal(t, fmt.Sprintf("web-%d", i+1), member.Name)
+	}
+
+	// Уменьшение отменяет только перечисленные серверы: по имени или UUID
+	d, err = testDedicatedServerGroupV1Update(ctx, t, d, meta, testDedicatedServerGroupV1Config(3, "web-1", members[2].UUID))
+	require.NoError(t, err)
+
+	kept := expandDedicatedServerGroupV1Members(d.Get("members"))
+	require.Len(t, kept, 3)
+	assert.Equal(t, members[1].UUID, kept[0].UUID)
+	for _, uuid := range []string{members[0].UUID, members[2].UUID} {
+		billing, ok := api.Billing(uuid)
+		require.True(t, ok)
+		assert.True(t, billing.CancelAtPeriodEnd)
+	}
+	billing, ok := api.Billing(kept[0].UUID)
+	require.True(t, ok)
+	assert.False(t, billing.CancelAtPeriodEnd)
+	assert.Len(t, api.Orders(), 2)
+
+	// Без remove_members провайдер не выбирает серверы для отмены сам
+	_, err = testDedicatedServerGroupV1Update(ctx, t, d, meta, testDedicatedServerGroupV1Config(2))
+	assert.ErrorContains(t, err, "remove_members")
+
+	diags = resourceDedicatedServerGroupV1Delete(ctx, d, meta)
+	require.False(t, diags.HasError(), diags)
+	for _, member := range kept {
+		billing, ok := api.Billing(member.UUID)
+		require.True(t, ok)
+		assert.True(t, billing.CancelAtPeriodEnd)
+	}
+}
+
+func TestDedicatedServerGroupV1ReorderGoneMemberFake(t *testing.T) {
+	api := fakeservers.New()
+	defer api.Close()
+
+	ctx := context.Background()
+	meta := testServersFakeConfig(t, api)
+	d := schema.TestResourceDataRaw(t, resourceDedicatedServerGroupV1().Schema, testDedicatedServerGroupV1Config(2))
+	require.False(t, resourceDedicatedServerGroupV1Create(ctx, d, meta).HasError())
+
+	members := expandDedicatedServerGroupV1Members(d.Get("members"))
+	require.Len(t, members, 2)
+	api.RemoveServer(members[0].UUID)
+
+	diags := resourceDedicatedServerGroupV1Read(ctx, d, meta)
+	require.False(t, diags.HasError(), diags)
+	require.Len(t, diags, 1)
+	assert.Equal(t, members[1:], expandDedicatedServerGroupV1Members(d.Get("members")))
+
+	d, err := testDedicatedServerGroupV1Update(ctx, t, d, meta, testDedicatedServerGroupV1Config(2))
+	require.NoError(t, err)
+
+	// Вместо web-1 дозаказывается web-3: номера не переиспользуются
+	orders := api.Orders()
+	require.Len(t, orders, 2)
+	assert.Equal(t, float64(1), orders[1]["quantity"])
+	members = expandDedicatedServerGroupV1Members(d.Get("members"))
+	require.Len(t, members, 2)
+	assert.Equal(t, "web-3", members[1].Name)
+}
+
+func TestDedicatedServerGroupV1RenamePendingFake(t *testing.T) {
+	api := fakeservers.New()
+	defer api.Close()
+
+	ctx := context.Background()
+	meta := testServersFakeConfig(t, api)
+	d := schema.TestResourceDataRaw(t, resourceDedicatedServerGroupV1().Schema, testDedicatedServerGroupV1Config(2))
+	require.False(t, resourceDedicatedServerGroupV1Create(ctx, d, meta).HasError())
+
+	// Числовой ID второго сервера не был известен при заказе, и сервер
+	// остался с именем группы
+	members := expandDedicatedServerGroupV1Members(d.Get("members"))
+	require.Len(t, members, 2)
+	serversService, err := meta.GetServersService()
+	require.NoError(t, err)
+	groupName := "web"
+	_, err = serversService.UpdateServer(ctx, members[1].ServerID(), &servers.DedicatedServerUpdate{Name: &groupName})
+	require.NoError(t, err)
+	pending := members[1]
+	pending.ID = ""
+	require.NoError(t, d.Set("members", flattenDedicatedServerGroupV1Members([]dedicatedServerGroupV1Member{members[0], pending})))
+
+	require.False(t, resourceDedicatedServerGroupV1Read(ctx, d, meta).HasError())
+	assert.Equal(t, members, expandDedicatedServerGroupV1Members(d.Get("members")))
+
+	// Следующее изменение группы переименовывает сервер
+	raw := testDedicatedServerGroupV1Config(2)
+	raw["auto_renewal"] = true
+	d, err = testDedicatedServerGroupV1Update(ctx, t, d, meta, raw)
+	require.NoError(t, err)
+
+	server, ok := api.Server(members[1].UUID)
+	require.True(t, ok)
+	assert.Equal(t, "web-2", server.Name)
+	assert.Equal(t, members, expandDedicatedServerGroupV1Members(d.Get("members")))
+}
+
+func TestDedicatedServerGroupV1RetryOnConflictFake(t *testing.T) {
+	testDedicatedServerConflictRetryDelay(t)
+
+	api := fakeservers.New()
+	defer api.Close()
+
+	ctx := context.Background()
+	meta := testServersFakeConfig(t, api)
+	d := schema.TestResourceDataRaw(t, resourceDedicatedServerGroupV1().Schema, testDedicatedServerGroupV1Config(2))
+	require.False(t, resourceDedicatedServerGroupV1Create(ctx, d, meta).HasError())
+
+	members := expandDedicatedServerGroupV1Members(d.Get("members"))
+	require.Len(t, members, 2)
+
+	// Каждый вызов аренды один раз отклоняется из-за чужой задачи
+	for _, hook := range []fakeservers.Hook{
+		{Method: http.MethodDelete, Path: "resource/serverchip/billing/" + members[0].UUID},
+		{Method: http.MethodPatch, Path: "resource/serverchip/billing/" + members[1].UUID},
+		{Method: http.MethodPut, Path: "resource/serverchip/" + members[1].UUID + "/labels"},
+	} {
+		hook.Status = http.StatusConflict
+		hook.Times = 1
+		api.AddHook(hook)
+	}
+
+	raw := testDedicatedServerGroupV1Config(1, members[0].UUID)
+	raw["auto_renewal"] = true
+	raw["labels"] = map[string]interface{}{"env": "prod"}
+	d, err := testDedicatedServerGroupV1Update(ctx, t, d, meta, raw)
+	require.NoError(t, err)
+
+	billing, ok := api.Billing(members[0].UUID)
+	require.True(t, ok)
+	assert.True(t, billing.CancelAtPeriodEnd)
+	billing, ok = api.Billing(members[1].UUID)
+	require.True(t, ok)
+	assert.True(t, billing.AutoRenewal)
+	server, ok := api.Server(members[1].UUID)
+	require.True(t, ok)
+	assert.Equal(t, "prod", server.Labels["env"])
+
+	api.AddHook(fakeservers.Hook{
+		Method: http.MethodDelete,
+		Path:   "resource/serverchip/billing/" + members[1].UUID,
+		Status: http.StatusConflict,
+		Times:  1,
+	})
+	diags := resourceDedicatedServerGroupV1Delete(ctx, d, meta)
+	require.False(t, diags.HasError(), diags)
+	billing, ok = api.Billing(members[1].UUID)
+	require.True(t, ok)
+	assert.True(t, billing.CancelAtPeriodEnd)
+}
+
+func TestDedicatedServerGroupV1DefaultLabelsFake(t *testing.T) {
+	api := fakeservers.New()
+	defer api.Close()
+
+	ctx := context.Background()
+	meta := testServersFakeConfig(t, api)
+	meta.DefaultLabels = map[string]string{"team": "infra"}
+
+	raw := testDedicatedServerGroupV1Config(2)
+	raw["labels"] = map[string]interface{}{"role": "web"}
+	d := schema.TestResourceDataRaw(t, resourceDedicatedServerGroupV1().Schema, raw)
+	require.False(t, resourceDedicatedServerGroupV1Create(ctx, d, meta).HasError())
+	assert.Equal(t, map[string]interface{}{"team": "infra", "role": "web"}, d.Get("labels_all"))
+
+	diff, _, err := testServersFakePlan(ctx, t, resourceDedicatedServerGroupV1(), d, meta, raw)
+	require.NoError(t, err)
+	assert.True(t, diff == nil || diff.Empty(), diff)
+
+	// Новые default_labels доходят до всех серверов группы
+	meta.DefaultLabels = map[string]string{"team": "platform"}
+	diags := resourceDedicatedServerGroupV1Read(ctx, d, meta)
+	require.False(t, diags.HasError(), diags)
+
+	diff, _, err = testServersFakePlan(ctx, t, resourceDedicatedServerGroupV1(), d, meta, raw)
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	assert.Contains(t, diff.Attributes, "labels_all.team")
+
+	d, err = testDedicatedServerGroupV1Update(ctx, t, d, meta, raw)
+	require.NoError(t, err)
+	for _, member := range expandDedicatedServerGroupV1Members(d.Get("members")) {
+		server, ok := api.Server(member.UUID)
+		require.True(t, ok)
+		assert.Equal(t, map[string]string{"team": "platform", "role": "web"}, server.Labels)
+	}
+}
+
+func TestDedicatedServerGroupV1ReadFake(t *testing.T) {
+	api := fakeservers.New()
+	defer api.Close()
+
+	ctx := context.Background()
+	meta := testServersFakeConfig(t, api)
+	d := schema.TestResourceDataRaw(t, resourceDedicatedServerGroupV1().Schema, testDedicatedServerGroupV1Config(2))
+	require.False(t, resourceDedicatedServerGroupV1Create(ctx, d, meta).HasError())
+
+	members := expandDedicatedServerGroupV1Members(d.Get("members"))
+	require.Len(t, members, 2)
+
+	// Второй сервер еще не получил числовой ID, а первому поменяли аренду и
+	// метки вне Terraform
+	provisioning := members[1]
+	provisioning.ID = ""
+	provisioning.Status = ""
+	provisioning.PrimaryIP = ""
+	require.NoError(t, d.Set("members", flattenDedicatedServerGroupV1Members([]dedicatedServerGroupV1Member{members[0], provisioning})))
+	api.SetProvisioning(provisioning.UUID, true)
+
+	serversService, err := meta.GetServersService()
+	require.NoError(t, err)
+	autoRenewal := true
+	_, err = serversService.UpdateServerBilling(ctx, members[0].UUID, &servers.ServerBillingUpdate{AutoRenewal: &autoRenewal})
+	require.NoError(t, err)
+	require.NoError(t, serversService.SetServerLabels(ctx, members[0].UUID, &servers.ServerLabels{Labels: map[string]string{"env": "dev"}}))
+
+	diags := resourceDedicatedServerGroupV1Read(ctx, d, meta)
+	require.False(t, diags.HasError(), diags)
+	assert.Empty(t, diags)
+
+	refreshed := expandDedicatedServerGroupV1Members(d.Get("members"))
+	require.Len(t, refreshed, 2)
+	assert.Equal(t, provisioning, refreshed[1])
+	assert.Equal(t, true, d.Get("auto_renewal"))
+	assert.Equal(t, map[string]interface{}{"env": "dev"}, d.Get("labels"))
+
+	// Когда сервер появляется, его числовой ID находится по UUID
+	api.SetProvisioning(provisioning.UUID, false)
+	diags = resourceDedicatedServerGroupV1Read(ctx, d, meta)
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, members, expandDedicatedServerGroupV1Members(d.Get("members")))
+}
+
+func TestDedicatedServerGroupV1ImportFake(t *testing.T) {
+	api := fakeservers.New()
+	defer api.Close()
+
+	ctx := context.Background()
+	meta := testServersFakeConfig(t, api)
+	created := schema.TestResourceDataRaw(t, resourceDedicatedServerGroupV1().Schema, testDedicatedServerGroupV1Config(2))
+	require.False(t, resourceDedicatedServerGroupV1Create(ctx, created, meta).HasError())
+	members := expandDedicatedServerGroupV1Members(created.Get("members"))
+
+	d := schema.TestResourceDataRaw(t, resourceDedicatedServerGroupV1().Schema, map[string]interface{}{})
+	d.SetId(members[0].UUID + "," + members[1].UUID)
+
+	imported, err := resourceDedicatedServerGroupV1ImportState(ctx, d, meta)
+	require.NoError(t, err)
+	require.Len(t, imported, 1)
+	require.False(t, resourceDedicatedServerGroupV1Read(ctx, imported[0], meta).HasError())
+
+	assert.NotEqual(t, members[0].UUID+","+members[1].UUID, imported[0].Id())
+	assert.Equal(t, "web", imported[0].Get("name"))
+	assert.Equal(t, 2, imported[0].Get("quantity"))
+	assert.Equal(t, members, expandDedicatedServerGroupV1Members(imported[0].Get("members")))
+
+	// Спецификацию нельзя прочитать с серверов, но план после импорта пуст
+	raw := testDedicatedServerGroupV1Config(2)
+	raw["raid_type"] = "RAID0"
+	raw["ssh_keys"] = []interface{}{"ssh-ed25519 AAAAIKey1 user@host"}
+	diff, _, err := testServersFakePlan(ctx, t, resourceDedicatedServerGroupV1(), imported[0], meta, raw)
+	require.NoError(t, err)
+	assert.True(t, diff == nil || diff.Empty(), diff)
+
+	// Дозаказ берет спецификацию из конфигурации
+	raw["quantity"] = 3
+	diff, _, err = testServersFakePlan(ctx, t, resourceDedicatedServerGroupV1(), imported[0], meta, raw)
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	assert.False(t, diff.RequiresNew(), diff)
+
+	d, err = testDedicatedServerGroupV1Update(ctx, t, imported[0], meta, raw)
+	require.NoError(t, err)
+	assert.Equal(t, 20, d.Get("root_size"))
+	assert.Equal(t, "RAID0", d.Get("raid_type"))
+	require.Len(t, d.Get("members").([]interface{}), 3)
+
+	orders := api.Orders()
+	require.Len(t, orders, 2)
+	assert.Equal(t, float64(1), orders[1]["quantity"])
+	assert.Contains(t, fmt.Sprint(orders[1]), "AAAAIKey1")
+
+	// После этого изменение спецификации снова пересоздает группу
+	raw["root_size"] = 30
+	diff, _, err = testServersFakePlan(ctx, t, resourceDedicatedServerGroupV1(), d, meta, raw)
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	assert.True(t, diff.RequiresNew())
+}
+
+func testAccCheckDedicatedServerGroupV1Destroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+	serversService, err := config.GetServersService()
+	if err != nil {
+		return fmt.Errorf("can't get servers service for test: %w", err)
+	}
+
+	ctx := context.Background()
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "selectel_dedicated_server_group_v1" {
+			continue
+		}
+
+		count, _ := strconv.Atoi(rs.Primary.Attributes["members.#"])
+		for i := 0; i < count; i++ {
+			uuid := rs.Primary.Attributes[fmt.Sprintf("members.%d.uuid", i)]
+			billing, err := serversService.GetServerBilling(ctx, uuid)
+			if err == nil && !isServerGone(billing.Status) && billing.AutoRenewal {
+				return errors.New("dedicated server of the group is still rented")
+			}
+		}
+	}
+
+	return nil
+}
+
+func testAccDedicatedServerGroupV1Basic(name string, quantity int, removeMembers string) string {
+	return fmt.Sprintf(`
+resource "selectel_dedicated_server_group_v1" "group_tf_acc_test_1" {
+  name           = "%s"
+  quantity       = %d
+  location_id    = 1
+  root_size      = 20
+  remove_members = [%s]
+  cancel_mode    = "immediate"
+
+  timeouts {
+    create = "60m"
+    update = "60m"
+  }
+}`, name, quantity, removeMembers)
+}
@@ -2,6 +2,7 @@ package selectel
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"strconv"
@@ -9,9 +10,11 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/servers"
 )
 
 func resourceDedicatedServerV1() *schema.Resource {
@@ -28,6 +31,12 @@ func resourceDedicatedServerV1() *schema.Resource {
 			Update: schema.DefaultTimeout(30 * time.Minute),
 			Delete: schema.DefaultTimeout(30 * time.Minute),
 		},
+		CustomizeDiff: customdiff.All(
+			dedicatedServerV1LabelsCustomizeDiff,
+			dedicatedServerV1DiskLayoutCustomizeDiff,
+			dedicatedServerV1ReinstallCustomizeDiff,
+			customdiff.ValidateChange("prolong_periods", dedicatedServerV1ValidateProlongPeriods),
+		),
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -51,9 +60,25 @@ func resourceDedicatedServerV1() *schema.Resource {
 			"os_id": {
 				Type:        schema.TypeInt,
 				Optional:    true,
-				ForceNew:    true,
 				Description: "ID of the operating system",
 			},
+			"os_template_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "UUID of a custom install template from selectel_dedicated_os_template_v1. Without os_id the operating system the template is based on is installed",
+			},
+			"user_data": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Cloud-init user data passed to the OS installer",
+			},
+			"reinstall_on_change": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Reinstall the OS on the same server instead of replacing it when the OS, SSH keys, " +
+					"user_data or disk layout change. IP addresses and the rental are kept",
+			},
 			"comment": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -67,6 +92,83 @@ func resourceDedicatedServerV1() *schema.Resource {
 				},
 				Description: "Tags for the server",
 			},
+			"labels": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				ValidateFunc: validateServerLabels,
+				Description:  "Key/value labels for the server, merged with the provider default_labels",
+			},
+			"labels_all": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "All labels of the server including the provider default_labels",
+			},
+			"power_state": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					servers.ServerPowerStateOn, servers.ServerPowerStateOff,
+				}, false),
+				Description: "Desired power state of the server: on or off",
+			},
+			"billing_period": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  servers.ServerBillingPeriodMonthly,
+				ValidateFunc: validation.StringInSlice([]string{
+					servers.ServerBillingPeriodHourly, servers.ServerBillingPeriodMonthly,
+					servers.ServerBillingPeriodQuarterly, servers.ServerBillingPeriodAnnually,
+				}, false),
+				Description: "Billing period of the rental: hourly, monthly, quarterly or annually",
+			},
+			"price_plan_uuid": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "UUID of the price plan to order the server with. By default the billing applies the plan of billing_period",
+			},
+			"auto_renewal": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Renew the rental automatically at the end of the paid period",
+			},
+			"prolong_periods": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Number of billing periods paid in advance. Increasing the value prolongs the rental by the difference. The value can't be decreased",
+			},
+			"cancel_mode": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  servers.ServerCancelModePeriodEnd,
+				ValidateFunc: validation.StringInSlice([]string{
+					servers.ServerCancelModeImmediate, servers.ServerCancelModePeriodEnd,
+				}, false),
+				Description: "How the rental is cancelled on destroy: immediate or period_end",
+			},
+			"wait_for_stock": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Wait until the configuration is in stock in the location before ordering, bounded by the create timeout",
+			},
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Refuse to destroy the server. Mirrored to the server-side lock",
+			},
+			"paid_until": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "End of the paid rental period",
+			},
 			"enable_ipmi": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -84,11 +186,18 @@ func resourceDedicatedServerV1() *schema.Resource {
 			"ssh_keys": {
 				Type:     schema.TypeList,
 				Optional: true,
-				ForceNew: true,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
-				Description: "SSH public keys for server access",
+				Description: "SSH public keys for server access. Applied when the OS is installed: a change reinstalls the OS with reinstall_on_change, otherwise it is kept for the next install without replacing the server",
+			},
+			"ssh_key_ids": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "UUIDs or names of SSH keys registered with selectel_dedicated_ssh_key_v1. Applied when the OS is installed: a change reinstalls the OS with reinstall_on_change, otherwise it is kept for the next install without replacing the server",
 			},
 			"network_config": {
 				Type:     schema.TypeList,
@@ -115,13 +224,11 @@ func resourceDedicatedServerV1() *schema.Resource {
 			},
 			// Простые поля для конфигурации разделов
 			"raid_type": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "RAID1",
-				Description: "RAID type: No RAID, RAID0, RAID1",
-				ValidateFunc: validation.StringInSlice([]string{
-					"No RAID", "RAID0", "RAID1",
-				}, false),
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "RAID1",
+				Description:  "RAID level of the system disks: No RAID, RAID0, RAID1, RAID5, RAID6, RAID10",
+				ValidateFunc: validation.StringInSlice(serverRAIDLevelNames(), false),
 			},
 			"swap_size": {
 				Type:        schema.TypeInt,
@@ -141,9 +248,14 @@ func resourceDedicatedServerV1() *schema.Resource {
 					Schema: map[string]*schema.Schema{
 						"mount": {
 							Type:        schema.TypeString,
-							Required:    true,
+							Optional:    true,
 							Description: "Mount point (e.g., /var, /home)",
 						},
+						"volume_group": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Name of a volume_group to use the partition as an LVM physical volume instead of mounting it",
+						},
 						"fstype": {
 							Type:        schema.TypeString,
 							Optional:    true,
@@ -162,7 +274,153 @@ func resourceDedicatedServerV1() *schema.Resource {
 				},
 				Description: "Additional custom partitions",
 			},
+			"raid_array": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ConflictsWith: []string{"raid_type"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"level": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "RAID level: No RAID, RAID0, RAID1, RAID5, RAID6, RAID10",
+							ValidateFunc: validation.StringInSlice(serverRAIDLevelNames(), false),
+						},
+						"members": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Description:  "Number of disks in the array, defaults to the minimum for the level",
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"disk_group": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     serverDefaultDiskGroup,
+							Description: "Type of disks to build the array from (e.g., SSD SATA, HDD SATA, SSD NVMe)",
+						},
+						"disk_size": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Description:  "Size of disks to build the array from in GB",
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"partition": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"mount": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "Mount point (e.g., /data)",
+									},
+									"volume_group": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "Name of a volume_group to use the partition as an LVM physical volume instead of mounting it",
+									},
+									"fstype": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Default:     "ext4",
+										Description: "Filesystem type (ext4, xfs, etc.)",
+										ValidateFunc: validation.StringInSlice([]string{
+											"ext4", "ext3", "xfs", "btrfs", "swap",
+										}, false),
+									},
+									"size": {
+										Type:        schema.TypeInt,
+										Required:    true,
+										Description: "Partition size in GB, -1 to use the remaining space",
+									},
+								},
+							},
+							Description: "Partitions created on the array",
+						},
+					},
+				},
+				Description: "Software RAID arrays; the first one holds /boot, swap, / and custom_partitions",
+			},
+			"volume_group": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "Name of the LVM volume group",
+							ValidateFunc: validation.StringMatch(serverLVMNameRegexp, "must contain only letters, digits, '.', '_', '+' and '-'"),
+						},
+						"thin_pool": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:         schema.TypeString,
+										Required:     true,
+										Description:  "Name of the thin pool",
+										ValidateFunc: validation.StringMatch(serverLVMNameRegexp, "must contain only letters, digits, '.', '_', '+' and '-'"),
+									},
+									"size": {
+										Type:        schema.TypeInt,
+										Required:    true,
+										Description: "Thin pool size in GB, -1 to use the remaining space",
+									},
+								},
+							},
+							Description: "Thin pools of the volume group",
+						},
+						"logical_volume": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:         schema.TypeString,
+										Required:     true,
+										Description:  "Name of the logical volume",
+										ValidateFunc: validation.StringMatch(serverLVMNameRegexp, "must contain only letters, digits, '.', '_', '+' and '-'"),
+									},
+									"mount": {
+										Type:        schema.TypeString,
+										Required:    true,
+										Description: "Mount point (e.g., /var/lib/docker)",
+									},
+									"fstype": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Default:     "ext4",
+										Description: "Filesystem type (ext4, xfs, etc.)",
+										ValidateFunc: validation.StringInSlice([]string{
+											"ext4", "ext3", "xfs", "btrfs", "swap",
+										}, false),
+									},
+									"size": {
+										Type:        schema.TypeInt,
+										Required:    true,
+										Description: "Logical volume size in GB, -1 to use the remaining space",
+									},
+									"thin_pool": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "Name of a thin pool of this volume group to create a thin volume in",
+									},
+								},
+							},
+							Description: "Logical volumes of the volume group",
+						},
+					},
+				},
+				Description: "LVM volume groups built from partitions with volume_group set",
+			},
 			// Computed fields
+			"server_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Numeric ID of the server used for reinstallation",
+			},
 			"status": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -289,26 +547,50 @@ func resourceDedicatedServerV1() *schema.Resource {
 							Type:     schema.TypeInt,
 							Computed: true,
 						},
-						"name": {
+						"uuid": {
 							Type:     schema.TypeString,
 							Computed: true,
 						},
-						"code": {
+						"name": {
 							Type:     schema.TypeString,
 							Computed: true,
 						},
-						"country": {
+						"description": {
 							Type:     schema.TypeString,
 							Computed: true,
 						},
-						"city": {
+						"region": {
 							Type:     schema.TypeString,
 							Computed: true,
 						},
-						"datacenter": {
+						"dc_count": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"visibility": {
 							Type:     schema.TypeString,
 							Computed: true,
 						},
+						"code": {
+							Type:       schema.TypeString,
+							Computed:   true,
+							Deprecated: "`code` is deprecated and will be removed in the next major version, use `name` instead",
+						},
+						"country": {
+							Type:       schema.TypeString,
+							Computed:   true,
+							Deprecated: "`country` is deprecated and will be removed in the next major version: the API doesn't return it, use `region` instead",
+						},
+						"city": {
+							Type:       schema.TypeString,
+							Computed:   true,
+							Deprecated: "`city` is deprecated and will be removed in the next major version: the API doesn't return it, use `region` instead",
+						},
+						"datacenter": {
+							Type:       schema.TypeString,
+							Computed:   true,
+							Deprecated: "`datacenter` is deprecated and will be removed in the next major version, use `description` instead",
+						},
 					},
 				},
 				Description: "Server location information",
@@ -424,6 +706,7 @@ func resourceDedicatedServerV1() *schema.Resource {
 }
 
 func resourceDedicatedServerV1Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	createDeadline := time.Now().Add(d.Timeout(schema.TimeoutCreate))
 	config := meta.(*Config)
 	serversService, err := config.GetServersService()
 	if err != nil {
@@ -431,7 +714,7 @@ func resourceDedicatedServerV1Create(ctx context.Context, d *schema.ResourceData
 	}
 
 	// Подготавливаем данные для создания сервера
-	createOpts := &DedicatedServerCreate{
+	createOpts := &servers.DedicatedServerCreate{
 		Name:       d.Get("name").(string),
 		LocationID: d.Get("location_id").(int),
 	}
@@ -464,133 +747,33 @@ func resourceDedicatedServerV1Create(ctx context.Context, d *schema.ResourceData
 		createOpts.EnableBackup = v.(bool)
 	}
 
-	if v, ok := d.GetOk("ssh_keys"); ok {
-		sshKeys := make([]string, len(v.([]interface{})))
-		for i, key := range v.([]interface{}) {
-			sshKeys[i] = key.(string)
-		}
-		createOpts.SSHKeys = sshKeys
+	createOpts.SSHKeys, err = expandDedicatedServerSSHKeys(ctx, d, serversService)
+	if err != nil {
+		return diag.FromErr(errCreatingObject(objectDedicatedServer, err))
 	}
 
 	if v, ok := d.GetOk("network_config"); ok {
 		networkConfig := v.([]interface{})[0].(map[string]interface{})
-		createOpts.NetworkConfig = &DedicatedServerNetworkCreate{
+		createOpts.NetworkConfig = &servers.DedicatedServerNetworkCreate{
 			AdditionalIPs:  networkConfig["additional_ips"].(int),
 			PrivateNetwork: networkConfig["private_network"].(bool),
 		}
 	}
 
-	// Получаем простые параметры конфигурации разделов от пользователя
-	raidType := d.Get("raid_type").(string)
-	swapSize := d.Get("swap_size").(int)
-	rootSize := d.Get("root_size").(int)
+	log.Printf("[DEBUG] Creating %s %s in location %d with config %d and OS %d",
+		objectDedicatedServer, createOpts.Name, createOpts.LocationID, createOpts.ConfigID, createOpts.OSID)
 
-	// Получаем пользовательские разделы
-	var customPartitions []map[string]interface{}
-	if v, ok := d.GetOk("custom_partitions"); ok {
-		for _, partition := range v.([]interface{}) {
-			customPartitions = append(customPartitions, partition.(map[string]interface{}))
-		}
+	billingOpts, err := expandDedicatedServerBillingOpts(ctx, d, config, serversService, createOpts.Name, createOpts.SSHKeys)
+	if err != nil {
+		return diag.FromErr(errCreatingObject(objectDedicatedServer, err))
 	}
 
-	log.Printf("[DEBUG] Creating %s with options: %+v", objectDedicatedServer, createOpts)
-	log.Printf("[DEBUG] Partition config: RAID=%s, Swap=%dGB, Root=%dGB, Custom=%d", raidType, swapSize, rootSize, len(customPartitions))
-
-	// Создаем полную структуру partitions_config как в скриншоте (только партиции)
-	partitionsConfig := map[string]interface{}{
-		// Массив конфигураций партиций
-		"258611b6-0647-5f3c-a2ec-b686355e58b5": map[string]interface{}{
-			"type": "local_drive",
-			"match": map[string]interface{}{
-				"size": 479,
-				"type": "SSD SATA",
-			},
-		},
-		"572727a2-ca93-4bb3-ac76-8dbdfc2063a5": map[string]interface{}{
-			"type":  "soft_raid",
-			"level": "raid1",
-			"members": []string{
-				"ea7561eb-ba7c-4406-8233-e25d7dab8bc7",
-				"ddddb486-09b2-4844-9b82-db272be19f58",
-			},
-		},
-		"afa399ed-5bdb-5a40-8bb1-a9e181d2a85b": map[string]interface{}{
-			"type": "local_drive",
-			"match": map[string]interface{}{
-				"size": 479,
-				"type": "SSD SATA",
-			},
-		},
-		"b22b0c07-9fbf-477a-b7ad-a15f721d647c": map[string]interface{}{
-			"type":     "partition",
-			"device":   "258611b6-0647-5f3c-a2ec-b686355e58b5",
-			"priority": 0,
-			"size":     1,
-		},
-		"da198307-e2a8-4ed3-9aec-1c4d61c329c4": map[string]interface{}{
-			"type":   "filesystem",
-			"fstype": "ext4",
-			"device": "ff613dce-ba76-4bfc-bcec-99ca3172df70", // Ссылка на partition
-			"mount":  "/",
-		},
-		"ddddb486-09b2-4844-9b82-db272be19f58": map[string]interface{}{
-			"type":     "partition",
-			"device":   "258611b6-0647-5f3c-a2ec-b686355e58b5",
-			"priority": 1,
-			"size":     5,
-		},
-		"e0ad703a-799a-4016-8871-0c0d14ee9bb7": map[string]interface{}{
-			"type":   "filesystem",
-			"fstype": "ext3",
-			"device": "b22b0c07-9fbf-477a-b7ad-a15f721d647c", // Ссылка на partition
-			"mount":  "/boot",
-		},
-		"ea7561eb-ba7c-4406-8233-e25d7dab8bc7": map[string]interface{}{
-			"type":     "partition",
-			"device":   "afa399ed-5bdb-5a40-8bb1-a9e181d2a85b",
-			"priority": 1,
-			"size":     5,
-		},
-		"f586bb41-ff4e-4aae-8f29-16023e36e985": map[string]interface{}{
-			"type":     "partition",
-			"device":   "afa399ed-5bdb-5a40-8bb1-a9e181d2a85b",
-			"priority": 0,
-			"size":     1,
-		},
-		"ff613dce-ba76-4bfc-bcec-99ca3172df70": map[string]interface{}{
-			"type":     "partition",
-			"device":   "afa399ed-5bdb-5a40-8bb1-a9e181d2a85b",
-			"priority": 2,
-			"size":     1,
-		},
+	stockRequest := serverStockRequest{
+		ServiceUUID:  billingOpts.ServiceUUID,
+		ConfigID:     createOpts.ConfigID,
+		LocationUUID: billingOpts.LocationUUID,
 	}
-
-	// ИСПРАВЛЕННЫЕ ПАРАМЕТРЫ: добавляем все обязательные поля
-	billingOpts := &DedicatedServerCreateBilling{
-		Name:          createOpts.Name,
-		LocationUUID:  "b7d55bf4-7057-5113-85c8-141871bf7635", // SPB-4
-		ServiceUUID:   "7a7d09db-0915-46a1-93f6-5e3024709325", // AR21-SSD (правильный UUID)
-		PricePlanUUID: "be6c2a5f-4160-5145-8695-c628496b208d", // Правильный из скриншота
-		OSTemplate:    "debian",                               // Debian (проверенная OS)
-		Arch:          "x86_64",
-		Version:       "12v2",
-		UserHostname:  createOpts.Name,
-		PayCurrency:   "main",
-		UserDesc:      "Terraform managed server",
-		// Используем полную конфигурацию partitions_config
-		PartitionsConfig: partitionsConfig,
-	}
-
-	log.Printf("[DEBUG] BillingOpts details: Name=%s, LocationUUID=%s",
-		billingOpts.Name, billingOpts.LocationUUID)
-	log.Printf("[DEBUG] UserHostname='%s', UserDesc='%s'", partitionsConfig["userhostname"], partitionsConfig["user_desc"])
-	log.Printf("[DEBUG] Full billingOpts: %+v", billingOpts)
-
-	// Дополнительное логирование перед отправкой
-	log.Printf("[DEBUG] FINAL CHECK: UserHostname='%s', UserDesc='%s'", partitionsConfig["userhostname"], partitionsConfig["user_desc"])
-
-	// Используем новый эндпоинт для создания сервера через биллинг
-	response, err := serversService.CreateServerResource(ctx, billingOpts)
+	response, err := placeDedicatedServerOrder(ctx, serversService, billingOpts, stockRequest, d.Get("wait_for_stock").(bool), createDeadline)
 	if err != nil {
 		return diag.FromErr(errCreatingObject(objectDedicatedServer, err))
 	}
@@ -603,8 +786,39 @@ func resourceDedicatedServerV1Create(ctx context.Context, d *schema.ResourceData
 	serverUUID := response.Result[0].UUID
 	d.SetId(serverUUID) // Временно используем UUID как ID
 
+	// Числовой ID нужен для действий над сервером, например переустановки
+	if serverID, err := strconv.Atoi(response.Result[0].ID); err == nil {
+		d.Set("server_id", serverID)
+	}
+
 	log.Printf("[DEBUG] Created %s %s, task: %s", objectDedicatedServer, serverUUID, response.TaskID)
 
+	defer lockDedicatedServerV1(d)()
+
+	if periods := d.Get("prolong_periods").(int); periods > 0 {
+		log.Printf("[DEBUG] Prolonging %s %s for %d periods", objectDedicatedServer, serverUUID, periods)
+		if _, err := serversService.ProlongServer(ctx, serverUUID, &servers.ServerBillingProlong{Periods: periods}); err != nil {
+			return diag.FromErr(errCreatingObject(objectDedicatedServer, err))
+		}
+	}
+
+	if d.Get("deletion_protection").(bool) {
+		if err := serversService.SetServerLock(ctx, serverUUID, &servers.ServerLock{Locked: true}); err != nil {
+			return diag.FromErr(errCreatingObject(objectDedicatedServer, err))
+		}
+	}
+
+	if v, ok := d.GetOk("power_state"); ok {
+		serverID, err := dedicatedServerV1NumericID(d)
+		if err != nil {
+			return diag.FromErr(errCreatingObject(objectDedicatedServer, err))
+		}
+		err = dedicatedServerV1EnsurePowerState(ctx, serversService, serverID, v.(string), time.Until(createDeadline))
+		if err != nil {
+			return diag.FromErr(errCreatingObject(objectDedicatedServer, err))
+		}
+	}
+
 	// ВРЕМЕННО ПРОПУСКАЕМ ОЖИДАНИЕ ГОТОВНОСТИ СЕРВЕРА для тестирования
 	// В новом API используется Task ID для отслеживания прогресса
 	log.Printf("[DEBUG] Server creation submitted, skipping state wait for testing")
@@ -622,10 +836,10 @@ func resourceDedicatedServerV1Read(ctx context.Context, d *schema.ResourceData,
 	// ВРЕМЕННОЕ ИСПРАВЛЕНИЕ: Проверяем, это UUID или старый integer ID
 	serverIDStr := d.Id()
 	if len(serverIDStr) > 10 { // UUID имеет длину 36 символов, integer ID - меньше
-		log.Printf("[DEBUG] Skipping read for new server with UUID: %s (new API format)", serverIDStr)
-		// Для новых серверов с UUID просто возвращаем success без чтения
+		log.Printf("[DEBUG] Reading billing of new server with UUID: %s (new API format)", serverIDStr)
+		// Для новых серверов с UUID читаем только параметры аренды
 		// В production версии нужно будет добавить метод GetServerByUUID
-		return nil
+		return dedicatedServerV1ReadBilling(ctx, d, serversService, config.DefaultLabels)
 	}
 
 	serverID, err := strconv.Atoi(serverIDStr)
@@ -637,17 +851,35 @@ func resourceDedicatedServerV1Read(ctx context.Context, d *schema.ResourceData,
 
 	server, err := serversService.GetServer(ctx, serverID)
 	if err != nil {
+		if errors.Is(err, servers.ErrNotFound) {
+			return dedicatedServerV1RemoveFromState(d, "not found")
+		}
 		return diag.FromErr(errGettingObject(objectDedicatedServer, d.Id(), err))
 	}
 
+	if isServerGone(server.Status) {
+		return dedicatedServerV1RemoveFromState(d, server.Status)
+	}
+
 	if err := d.Set("name", server.Name); err != nil {
 		return diag.FromErr(err)
 	}
 
+	if err := d.Set("server_id", server.ID); err != nil {
+		return diag.FromErr(err)
+	}
+
 	if err := d.Set("status", server.Status); err != nil {
 		return diag.FromErr(err)
 	}
 
+	// Переходные статусы (rebooting, installing) не считаем дрейфом питания
+	if powerState, ok := serverPowerStateFromStatus(server.Status); ok {
+		if err := d.Set("power_state", powerState); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	if err := d.Set("status_hd", server.StatusHD); err != nil {
 		return diag.FromErr(err)
 	}
@@ -708,6 +940,29 @@ func resourceDedicatedServerV1Read(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
+	if err := dedicatedServerV1SetLabels(d, config.DefaultLabels, server.Labels); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Аренда и блокировка хранятся в биллинге по UUID аренды
+	if server.UUID != "" {
+		billing, err := serversService.GetServerBilling(ctx, server.UUID)
+		if err != nil && !errors.Is(err, servers.ErrNotFound) {
+			return diag.FromErr(errGettingObject(objectDedicatedServer, d.Id(), err))
+		}
+		if billing != nil {
+			dedicatedServerV1SetBilling(d, billing)
+		}
+
+		lock, err := serversService.GetServerLock(ctx, server.UUID)
+		if err != nil {
+			return diag.FromErr(errGettingObject(objectDedicatedServer, d.Id(), err))
+		}
+		if lock != nil {
+			d.Set("deletion_protection", lock.Locked)
+		}
+	}
+
 	return nil
 }
 
@@ -718,11 +973,62 @@ func resourceDedicatedServerV1Update(ctx context.Context, d *schema.ResourceData
 		return diag.FromErr(err)
 	}
 
+	defer lockDedicatedServerV1(d)()
+
+	// Изменения ОС, user_data и разметки доходят до Update только с
+	// reinstall_on_change, иначе они пересоздают сервер. Ключи доступа с
+	// reinstall_on_change тоже переустанавливают ОС, без него только
+	// запоминаются до следующей установки
+	var diags diag.Diagnostics
+	if dedicatedServerV1NeedsReinstall(d) {
+		if err := dedicatedServerV1Reinstall(ctx, d, serversService, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(errUpdatingObject(objectDedicatedServer, d.Id(), err))
+		}
+	} else if d.HasChanges(dedicatedServerV1InstallOnlyKeys...) {
+		diags = append(diags, dedicatedServerV1SSHKeysWarning())
+	}
+
 	// ВРЕМЕННОЕ ИСПРАВЛЕНИЕ: Проверяем, это UUID или старый integer ID
 	serverIDStr := d.Id()
 	if len(serverIDStr) > 10 { // UUID имеет длину 36 символов, integer ID - меньше
-		log.Printf("[DEBUG] Skipping update for new server with UUID: %s (new API format)", serverIDStr)
-		return nil
+		log.Printf("[DEBUG] Updating billing of new server with UUID: %s (new API format)", serverIDStr)
+		if err := dedicatedServerV1UpdateBilling(ctx, d, serversService, serverIDStr); err != nil {
+			return diag.FromErr(errUpdatingObject(objectDedicatedServer, d.Id(), err))
+		}
+
+		if d.HasChanges("labels", "labels_all") {
+			labels := mergeServerLabels(config.DefaultLabels, expandServerLabels(d.Get("labels")))
+			err := dedicatedServerV1RetryOnConflict(ctx, d, serversService, func() error {
+				return serversService.SetServerLabels(ctx, d.Id(), &servers.ServerLabels{Labels: labels})
+			})
+			if err != nil {
+				return diag.FromErr(errUpdatingObject(objectDedicatedServer, d.Id(), err))
+			}
+		}
+
+		if d.HasChange("deletion_protection") {
+			locked := d.Get("deletion_protection").(bool)
+			err := dedicatedServerV1RetryOnConflict(ctx, d, serversService, func() error {
+				return serversService.SetServerLock(ctx, d.Id(), &servers.ServerLock{Locked: locked})
+			})
+			if err != nil {
+				return diag.FromErr(errUpdatingObject(objectDedicatedServer, d.Id(), err))
+			}
+		}
+
+		if d.HasChange("power_state") {
+			serverID, err := dedicatedServerV1NumericID(d)
+			if err != nil {
+				return diag.FromErr(errUpdatingObject(objectDedicatedServer, d.Id(), err))
+			}
+			powerState := d.Get("power_state").(string)
+			err = dedicatedServerV1ApplyPowerState(ctx, serversService, serverID, powerState, d.Timeout(schema.TimeoutUpdate))
+			if err != nil {
+				return diag.FromErr(errUpdatingObject(objectDedicatedServer, d.Id(), err))
+			}
+		}
+
+		return append(diags, resourceDedicatedServerV1Read(ctx, d, meta)...)
 	}
 
 	serverID, err := strconv.Atoi(serverIDStr)
@@ -730,7 +1036,7 @@ func resourceDedicatedServerV1Update(ctx context.Context, d *schema.ResourceData
 		return diag.FromErr(err)
 	}
 
-	updateOpts := &DedicatedServerUpdate{}
+	updateOpts := &servers.DedicatedServerUpdate{}
 
 	if d.HasChange("name") {
 		name := d.Get("name").(string)
@@ -752,27 +1058,123 @@ func resourceDedicatedServerV1Update(ctx context.Context, d *schema.ResourceData
 		}
 	}
 
-	log.Printf("[DEBUG] Updating %s %d with options: %+v", objectDedicatedServer, serverID, updateOpts)
+	if d.HasChanges("labels", "labels_all") {
+		labels := mergeServerLabels(config.DefaultLabels, expandServerLabels(d.Get("labels")))
+		updateOpts.Labels = &labels
+	}
+
+	if d.HasChanges("name", "comment", "tags", "labels", "labels_all") {
+		log.Printf("[DEBUG] Updating %s %d with options: %+v", objectDedicatedServer, serverID, updateOpts)
+
+		err = dedicatedServerRetryOnConflict(ctx, serversService, serverID, d.Timeout(schema.TimeoutUpdate), func() error {
+			_, err := serversService.UpdateServer(ctx, serverID, updateOpts)
+			return err
+		})
+		if err != nil {
+			return diag.FromErr(errUpdatingObject(objectDedicatedServer, d.Id(), err))
+		}
+	}
+
+	// Аренда и блокировка хранятся в биллинге по UUID аренды, который
+	// возвращает API серверов
+	if d.HasChanges("billing_period", "auto_renewal", "prolong_periods", "deletion_protection") {
+		billingUUID, err := dedicatedServerV1BillingUUID(ctx, serversService, serverID)
+		if err != nil {
+			return diag.FromErr(errUpdatingObject(objectDedicatedServer, d.Id(), err))
+		}
 
-	_, err = serversService.UpdateServer(ctx, serverID, updateOpts)
+		if err := dedicatedServerV1UpdateBilling(ctx, d, serversService, billingUUID); err != nil {
+			return diag.FromErr(errUpdatingObject(objectDedicatedServer, d.Id(), err))
+		}
+
+		if d.HasChange("deletion_protection") {
+			lock := &servers.ServerLock{Locked: d.Get("deletion_protection").(bool)}
+			err := dedicatedServerRetryOnConflict(ctx, serversService, serverID, d.Timeout(schema.TimeoutUpdate), func() error {
+				return serversService.SetServerLock(ctx, billingUUID, lock)
+			})
+			if err != nil {
+				return diag.FromErr(errUpdatingObject(objectDedicatedServer, d.Id(), err))
+			}
+		}
+	}
+
+	if d.HasChange("power_state") {
+		powerState := d.Get("power_state").(string)
+		err = dedicatedServerV1ApplyPowerState(ctx, serversService, serverID, powerState, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return diag.FromErr(errUpdatingObject(objectDedicatedServer, d.Id(), err))
+		}
+	}
+
+	return append(diags, resourceDedicatedServerV1Read(ctx, d, meta)...)
+}
+
+// dedicatedServerV1BillingUUID возвращает UUID аренды сервера с числовым ID
+func dedicatedServerV1BillingUUID(ctx context.Context, serversService servers.ServersAPI, serverID int) (string, error) {
+	server, err := serversService.GetServer(ctx, serverID)
 	if err != nil {
-		return diag.FromErr(errUpdatingObject(objectDedicatedServer, d.Id(), err))
+		return "", err
+	}
+	if server.UUID == "" {
+		return "", fmt.Errorf("UUID of %s %d is unknown, its billing, deletion_protection and cancel_mode can't be managed", objectDedicatedServer, serverID)
 	}
 
-	return resourceDedicatedServerV1Read(ctx, d, meta)
+	return server.UUID, nil
+}
+
+// listDedicatedServersByUUID возвращает серверы с UUID аренды по этому UUID
+func listDedicatedServersByUUID(ctx context.Context, serversService servers.ServersAPI) (map[string]*servers.DedicatedServer, error) {
+	allServers, err := serversService.ListServers(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	byUUID := make(map[string]*servers.DedicatedServer, len(allServers))
+	for _, server := range allServers {
+		if server.UUID != "" {
+			byUUID[server.UUID] = server
+		}
+	}
+
+	return byUUID, nil
+}
+
+// dedicatedServerV1Cancel снимает сервер с аренды по UUID аренды с учетом
+// cancel_mode
+func dedicatedServerV1Cancel(ctx context.Context, d *schema.ResourceData, serversService servers.ServersAPI, serverID int, billingUUID string) error {
+	cancelMode := d.Get("cancel_mode").(string)
+	log.Printf("[DEBUG] Cancelling rental of %s %s (%s)", objectDedicatedServer, billingUUID, cancelMode)
+
+	return dedicatedServerRetryOnConflict(ctx, serversService, serverID, d.Timeout(schema.TimeoutDelete), func() error {
+		return serversService.CancelServerResource(ctx, billingUUID, &servers.ServerCancelOpts{Mode: cancelMode})
+	})
 }
 
 func resourceDedicatedServerV1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.Get("deletion_protection").(bool) {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Cannot destroy %s %s: deletion protection is enabled", objectDedicatedServer, d.Id()),
+			Detail: "The server has deletion_protection = true. Set it to false and apply " +
+				"before destroying the server.",
+		}}
+	}
+
 	config := meta.(*Config)
 	serversService, err := config.GetServersService()
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	defer lockDedicatedServerV1(d)()
+
 	// ВРЕМЕННОЕ ИСПРАВЛЕНИЕ: Проверяем, это UUID или старый integer ID
 	serverIDStr := d.Id()
 	if len(serverIDStr) > 10 { // UUID имеет длину 36 символов, integer ID - меньше
-		log.Printf("[DEBUG] Skipping delete for new server with UUID: %s (new API format)", serverIDStr)
+		if err := dedicatedServerV1Cancel(ctx, d, serversService, d.Get("server_id").(int), serverIDStr); err != nil {
+			return diag.FromErr(errDeletingObject(objectDedicatedServer, d.Id(), err))
+		}
+
 		return nil
 	}
 
@@ -781,15 +1183,34 @@ func resourceDedicatedServerV1Delete(ctx context.Context, d *schema.ResourceData
 		return diag.FromErr(err)
 	}
 
+	// Сервер, арендованный через биллинг, снимается с аренды так же, как
+	// сервер с UUID. Удаляются только серверы без аренды
+	server, err := serversService.GetServer(ctx, serverID)
+	if err != nil {
+		if errors.Is(err, servers.ErrNotFound) {
+			return nil
+		}
+		return diag.FromErr(errDeletingObject(objectDedicatedServer, d.Id(), err))
+	}
+	if server.UUID != "" {
+		if err := dedicatedServerV1Cancel(ctx, d, serversService, serverID, server.UUID); err != nil {
+			return diag.FromErr(errDeletingObject(objectDedicatedServer, d.Id(), err))
+		}
+
+		return nil
+	}
+
 	log.Printf("[DEBUG] Deleting %s %d", objectDedicatedServer, serverID)
 
-	err = serversService.DeleteServer(ctx, serverID)
+	err = dedicatedServerRetryOnConflict(ctx, serversService, serverID, d.Timeout(schema.TimeoutDelete), func() error {
+		return serversService.DeleteServer(ctx, serverID)
+	})
 	if err != nil {
 		return diag.FromErr(errDeletingObject(objectDedicatedServer, d.Id(), err))
 	}
 
 	stateConf := &resource.StateChangeConf{
-		Pending:        []string{ServerStatusActive, ServerStatusStopped},
+		Pending:        []string{servers.ServerStatusActive, servers.ServerStatusStopped},
 		Target:         []string{},
 		Refresh:        dedicatedServerV1DeleteStateRefreshFunc(ctx, serversService, serverID),
 		Timeout:        d.Timeout(schema.TimeoutDelete),
@@ -807,9 +1228,11 @@ func resourceDedicatedServerV1Delete(ctx context.Context, d *schema.ResourceData
 }
 
 func resourceDedicatedServerV1ImportState(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	// Импорт доступен с любыми учетными данными, которые принимает API
+	// серверов: servers_token или Keystone
 	config := meta.(*Config)
-	if config.ServersToken == "" {
-		return nil, fmt.Errorf("SEL_SERVERS_TOKEN must be set for the import")
+	if _, err := config.GetServersService(); err != nil {
+		return nil, err
 	}
 
 	parts := strings.Split(d.Id(), "/")
@@ -823,7 +1246,7 @@ func resourceDedicatedServerV1ImportState(_ context.Context, d *schema.ResourceD
 }
 
 // dedicatedServerV1StateRefreshFunc возвращает StateRefreshFunc для ожидания готовности сервера
-func dedicatedServerV1StateRefreshFunc(ctx context.Context, serversService *ServersService, serverID int) resource.StateRefreshFunc {
+func dedicatedServerV1StateRefreshFunc(ctx context.Context, serversService servers.ServersAPI, serverID int) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		server, err := serversService.GetServer(ctx, serverID)
 		if err != nil {
@@ -834,13 +1257,281 @@ func dedicatedServerV1StateRefreshFunc(ctx context.Context, serversService *Serv
 	}
 }
 
+// isServerGone сообщает, что сервер снят с аренды и больше не существует
+// для Terraform, хотя API еще возвращает его
+func isServerGone(status string) bool {
+	return status == servers.ServerStatusCancelled || status == servers.ServerStatusDecommissioned
+}
+
+// dedicatedServerV1RemoveFromState убирает сервер, удаленный вне Terraform,
+// из состояния, чтобы следующий план предложил создать его заново
+func dedicatedServerV1RemoveFromState(d *schema.ResourceData, reason string) diag.Diagnostics {
+	log.Printf("[WARN] %s %s is %s, removing it from state", objectDedicatedServer, d.Id(), reason)
+
+	id := d.Id()
+	d.SetId("")
+
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("%s %s is %s", objectDedicatedServer, id, reason),
+		Detail:   "The server was removed outside of Terraform and has been removed from the state. It will be re-created on the next apply.",
+	}}
+}
+
+// dedicatedServerV1SetLabels заполняет labels и labels_all по меткам из API
+func dedicatedServerV1SetLabels(d *schema.ResourceData, defaultLabels, all map[string]string) error {
+	configured := expandServerLabels(d.Get("labels"))
+	if err := d.Set("labels", serverLabelsWithoutDefaults(all, defaultLabels, configured)); err != nil {
+		return err
+	}
+
+	return d.Set("labels_all", all)
+}
+
+// dedicatedServerV1SetBilling заполняет период, автопродление и дату
+// окончания аренды
+func dedicatedServerV1SetBilling(d *schema.ResourceData, billing *servers.ServerBilling) {
+	if billing.Period != "" {
+		d.Set("billing_period", billing.Period)
+	}
+	d.Set("auto_renewal", billing.AutoRenewal)
+	if billing.PaidUntil != nil {
+		d.Set("paid_until", billing.PaidUntil.Format("2006-01-02T15:04:05Z"))
+	}
+}
+
+// dedicatedServerV1ReadBilling заполняет параметры аренды сервера
+func dedicatedServerV1ReadBilling(ctx context.Context, d *schema.ResourceData, serversService servers.ServersAPI, defaultLabels map[string]string) diag.Diagnostics {
+	billing, err := serversService.GetServerBilling(ctx, d.Id())
+	if err != nil {
+		if errors.Is(err, servers.ErrNotFound) {
+			return dedicatedServerV1RemoveFromState(d, "not found")
+		}
+		return diag.FromErr(errGettingObject(objectDedicatedServer, d.Id(), err))
+	}
+	if billing == nil {
+		return diag.FromErr(errReadFromResponse(objectDedicatedServer))
+	}
+	if isServerGone(billing.Status) {
+		return dedicatedServerV1RemoveFromState(d, billing.Status)
+	}
+
+	dedicatedServerV1SetBilling(d, billing)
+
+	labels, err := serversService.GetServerLabels(ctx, d.Id())
+	if err != nil {
+		return diag.FromErr(errGettingObject(objectDedicatedServer, d.Id(), err))
+	}
+	if labels != nil {
+		if err := dedicatedServerV1SetLabels(d, defaultLabels, labels.Labels); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	lock, err := serversService.GetServerLock(ctx, d.Id())
+	if err != nil {
+		return diag.FromErr(errGettingObject(objectDedicatedServer, d.Id(), err))
+	}
+	if lock != nil {
+		d.Set("deletion_protection", lock.Locked)
+	}
+
+	// Статус и питание читаются по числовому ID. У импортированного сервера
+	// и сервера, который еще готовился при заказе, его ищем по UUID
+	serverID := d.Get("server_id").(int)
+	if serverID == 0 {
+		byUUID, err := listDedicatedServersByUUID(ctx, serversService)
+		if err != nil {
+			return diag.FromErr(errGettingObject(objectDedicatedServer, d.Id(), err))
+		}
+		server, ok := byUUID[d.Id()]
+		if !ok || server.ID == 0 {
+			log.Printf("[DEBUG] Numeric ID of %s %s is unknown, skipping status", objectDedicatedServer, d.Id())
+			return nil
+		}
+		serverID = server.ID
+		d.Set("server_id", serverID)
+	}
+
+	server, err := serversService.GetServer(ctx, serverID)
+	if err != nil {
+		return diag.FromErr(errGettingObject(objectDedicatedServer, d.Id(), err))
+	}
+
+	d.Set("status", server.Status)
+	if powerState, ok := serverPowerStateFromStatus(server.Status); ok {
+		d.Set("power_state", powerState)
+	}
+
+	return nil
+}
+
+// dedicatedServerV1UpdateBilling применяет к аренде billingUUID изменения
+// периода оплаты, автопродления и продлевает аренду при увеличении
+// prolong_periods
+func dedicatedServerV1UpdateBilling(ctx context.Context, d *schema.ResourceData, serversService servers.ServersAPI, billingUUID string) error {
+	if d.HasChanges("billing_period", "auto_renewal") {
+		updateOpts := &servers.ServerBillingUpdate{}
+
+		if d.HasChange("billing_period") {
+			period := d.Get("billing_period").(string)
+			updateOpts.Period = &period
+		}
+
+		if d.HasChange("auto_renewal") {
+			autoRenewal := d.Get("auto_renewal").(bool)
+			updateOpts.AutoRenewal = &autoRenewal
+		}
+
+		log.Print(msgUpdate(objectDedicatedServer, billingUUID, updateOpts))
+		err := dedicatedServerV1RetryOnConflict(ctx, d, serversService, func() error {
+			_, err := serversService.UpdateServerBilling(ctx, billingUUID, updateOpts)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("prolong_periods") {
+		oldPeriods, newPeriods := d.GetChange("prolong_periods")
+		// Уменьшение значения отклоняется при планировании
+		if periods := newPeriods.(int) - oldPeriods.(int); periods > 0 {
+			log.Printf("[DEBUG] Prolonging %s %s for %d periods", objectDedicatedServer, billingUUID, periods)
+			err := dedicatedServerV1RetryOnConflict(ctx, d, serversService, func() error {
+				_, err := serversService.ProlongServer(ctx, billingUUID, &servers.ServerBillingProlong{Periods: periods})
+				return err
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// dedicatedServerV1ValidateProlongPeriods запрещает уменьшать
+// prolong_periods: оплаченные периоды не возвращаются, а последующее
+// увеличение оплатило бы их повторно
+func dedicatedServerV1ValidateProlongPeriods(_ context.Context, oldValue, newValue, _ interface{}) error {
+	oldPeriods, newPeriods := oldValue.(int), newValue.(int)
+	if newPeriods < oldPeriods {
+		return fmt.Errorf("prolong_periods can't be decreased from %d to %d: paid periods are not refunded", oldPeriods, newPeriods)
+	}
+
+	return nil
+}
+
+// dedicatedServerV1PowerStateDelay — пауза перед первой проверкой статуса
+// после смены питания
+var dedicatedServerV1PowerStateDelay = 5 * time.Second
+
+// serverPowerStateFromStatus сопоставляет статус сервера с состоянием питания.
+// Для переходных статусов возвращает false.
+func serverPowerStateFromStatus(status string) (string, bool) {
+	switch status {
+	case servers.ServerStatusActive:
+		return servers.ServerPowerStateOn, true
+	case servers.ServerStatusStopped:
+		return servers.ServerPowerStateOff, true
+	}
+
+	return "", false
+}
+
+// dedicatedServerV1EnsurePowerState дожидается окончания задачи на сервере,
+// например установки ОС после заказа, и приводит питание к powerState, если
+// оно отличается
+func dedicatedServerV1EnsurePowerState(ctx context.Context, serversService servers.ServersAPI, serverID int, powerState string, timeout time.Duration) error {
+	server, err := serversService.GetServer(ctx, serverID)
+	if err != nil {
+		return err
+	}
+
+	current, ok := serverPowerStateFromStatus(server.Status)
+	if !ok {
+		if err := waitForDedicatedServerIdle(ctx, serversService, serverID, timeout); err != nil {
+			return err
+		}
+		if server, err = serversService.GetServer(ctx, serverID); err != nil {
+			return err
+		}
+		current, _ = serverPowerStateFromStatus(server.Status)
+	}
+
+	if current == powerState {
+		return nil
+	}
+
+	return dedicatedServerV1ApplyPowerState(ctx, serversService, serverID, powerState, timeout)
+}
+
+// dedicatedServerV1ApplyPowerState включает или выключает сервер и ожидает
+// целевого статуса
+func dedicatedServerV1ApplyPowerState(ctx context.Context, serversService servers.ServersAPI, serverID int, powerState string, timeout time.Duration) error {
+	var (
+		task         *servers.ServerTaskStatus
+		err          error
+		targetStatus string
+	)
+
+	log.Printf("[DEBUG] Setting power state of %s %d to %s", objectDedicatedServer, serverID, powerState)
+
+	switch powerState {
+	case servers.ServerPowerStateOn:
+		targetStatus = servers.ServerStatusActive
+		err = dedicatedServerRetryOnConflict(ctx, serversService, serverID, timeout, func() (err error) {
+			task, err = serversService.StartServer(ctx, serverID)
+			return err
+		})
+	case servers.ServerPowerStateOff:
+		targetStatus = servers.ServerStatusStopped
+		err = dedicatedServerRetryOnConflict(ctx, serversService, serverID, timeout, func() (err error) {
+			task, err = serversService.StopServer(ctx, serverID)
+			return err
+		})
+	default:
+		return fmt.Errorf("unsupported power state: %s", powerState)
+	}
+	if err != nil {
+		return fmt.Errorf("error setting power state %s: %w", powerState, err)
+	}
+
+	if task != nil && task.ID != 0 {
+		taskCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		if _, err := serversService.WaitForTask(taskCtx, task.ID); err != nil {
+			return fmt.Errorf("error waiting for power task %d: %w", task.ID, err)
+		}
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{
+			servers.ServerStatusActive, servers.ServerStatusStopped, servers.ServerStatusRebooting, servers.ServerStatusMaintenance,
+		},
+		Target:     []string{targetStatus},
+		Refresh:    dedicatedServerV1StateRefreshFunc(ctx, serversService, serverID),
+		Timeout:    timeout,
+		Delay:      dedicatedServerV1PowerStateDelay,
+		MinTimeout: 3 * time.Second,
+	}
+
+	_, err = stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return fmt.Errorf("error waiting for server %d to become %s: %w", serverID, targetStatus, err)
+	}
+
+	return nil
+}
+
 // dedicatedServerV1DeleteStateRefreshFunc возвращает StateRefreshFunc для ожидания удаления сервера
-func dedicatedServerV1DeleteStateRefreshFunc(ctx context.Context, serversService *ServersService, serverID int) resource.StateRefreshFunc {
+func dedicatedServerV1DeleteStateRefreshFunc(ctx context.Context, serversService servers.ServersAPI, serverID int) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		server, err := serversService.GetServer(ctx, serverID)
 		if err != nil {
 			// Если сервер не найден, значит он удален
-			if strings.Contains(err.Error(), "404") || strings.Contains(err.Error(), "not found") {
+			if errors.Is(err, servers.ErrNotFound) {
 				return server, "", nil
 			}
 			return nil, "", err
@@ -849,91 +1540,3 @@ func dedicatedServerV1DeleteStateRefreshFunc(ctx context.Context, serversService
 		return server, server.Status, nil
 	}
 }
-
-// GenerateSelectelPartitionsConfig генерирует конфигурацию партиций как в фронтенде Selectel
-func GenerateSelectelPartitionsConfig(raidType string, swapSize, rootSize int, customPartitions []map[string]interface{}) interface{} {
-	// Создаем массив конфигураций партиций как в скриншоте
-	partitions := make([]map[string]interface{}, 0)
-
-	// 1. Local drive конфигурация (физические диски)
-	localDrive1 := map[string]interface{}{
-		"type": "local_drive",
-		"match": map[string]interface{}{
-			"size": 479,
-			"type": "SSD SATA",
-		},
-		"device": "258611b6-0647-5f3c-a2ec-b686355e58b5",
-	}
-	partitions = append(partitions, localDrive1)
-
-	// 2. Soft RAID конфигурация (если RAID1)
-	if raidType == "RAID1" {
-		softRaid := map[string]interface{}{
-			"type":  "soft_raid",
-			"level": "raid1",
-			"members": []string{
-				"f586bb41-ff4e-4aae-8f29-16023e36e985",
-				"b225b6c07-9fbf-477a-b7ad-a15f721d647c",
-			},
-		}
-		partitions = append(partitions, softRaid)
-	}
-
-	// 3. Filesystem для swap (без файловой системы)
-	swapFilesystem := map[string]interface{}{
-		"type":     "filesystem",
-		"fstype":   "swap",
-		"device":   "572727a2-ca93-4bb3-ac76-8dbdfc2063a5",
-		"mount":    "swap",
-		"priority": 2,
-		"size":     swapSize,
-	}
-	partitions = append(partitions, swapFilesystem)
-
-	// 4. Boot partition (обязательная, 1 ГБ)
-	bootPartition := map[string]interface{}{
-		"type":     "partition",
-		"device":   "258611b6-0647-5f3c-a2ec-b686355e58b5",
-		"priority": 0,
-		"size":     1, // 1 ГБ для boot
-		"mount":    "/boot",
-		"fstype":   "ext4",
-	}
-	partitions = append(partitions, bootPartition)
-
-	// 5. Root partition
-	rootPartition := map[string]interface{}{
-		"type":     "partition",
-		"device":   "258611b6-0647-5f3c-a2ec-b686355e58b5",
-		"priority": 1,
-		"size":     rootSize,
-		"mount":    "/",
-		"fstype":   "ext4",
-	}
-	partitions = append(partitions, rootPartition)
-
-	// 6. Добавляем пользовательские разделы
-	for _, customPartition := range customPartitions {
-		mount := customPartition["mount"].(string)
-		size := customPartition["size"].(int)
-		fstype := "ext4"
-		if customPartition["fstype"] != nil {
-			fstype = customPartition["fstype"].(string)
-		}
-
-		userPartition := map[string]interface{}{
-			"type":     "partition",
-			"device":   "258611b6-0647-5f3c-a2ec-b686355e58b5",
-			"priority": 2,
-			"size":     size,
-			"mount":    mount,
-			"fstype":   fstype,
-		}
-		partitions = append(partitions, userPartition)
-	}
-
-	// API ожидает объект, а не массив. Оборачиваем в объект
-	return map[string]interface{}{
-		"partitions": partitions,
-	}
-}
@@ -5,16 +5,24 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"regexp"
 	"strconv"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/fakeservers"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/servers"
 )
 
 func TestAccDedicatedServerV1Basic(t *testing.T) {
-	var server DedicatedServer
+	var server servers.DedicatedServer
 	serverName := acctest.RandomWithPrefix("tf-acc-server")
 	serverNameUpdated := acctest.RandomWithPrefix("tf-acc-server-updated")
 
@@ -92,6 +100,453 @@ func TestAccDedicatedServerV1ImportBasic(t *testing.T) {
 	})
 }
 
+func TestAccDedicatedServerV1PowerState(t *testing.T) {
+	serverName := acctest.RandomWithPrefix("tf-acc-server-power")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccSelectelDedicatedServersPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckDedicatedServerV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDedicatedServerV1PowerState(serverName, "on"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("selectel_dedicated_server_v1.server_tf_acc_test_3", "power_state", "on"),
+				),
+			},
+			{
+				Config: testAccDedicatedServerV1PowerState(serverName, "off"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("selectel_dedicated_server_v1.server_tf_acc_test_3", "power_state", "off"),
+					resource.TestCheckResourceAttr("selectel_dedicated_server_v1.server_tf_acc_test_3", "status", "stopped"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDedicatedServerV1Billing(t *testing.T) {
+	serverName := acctest.RandomWithPrefix("tf-acc-server-billing")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccSelectelDedicatedServersPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckDedicatedServerV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDedicatedServerV1Billing(serverName, false, 0),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("selectel_dedicated_server_v1.server_tf_acc_test_4", "billing_period", "monthly"),
+					resource.TestCheckResourceAttr("selectel_dedicated_server_v1.server_tf_acc_test_4", "auto_renewal", "false"),
+					resource.TestCheckResourceAttrSet("selectel_dedicated_server_v1.server_tf_acc_test_4", "paid_until"),
+				),
+			},
+			{
+				Config: testAccDedicatedServerV1Billing(serverName, true, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("selectel_dedicated_server_v1.server_tf_acc_test_4", "auto_renewal", "true"),
+					resource.TestCheckResourceAttr("selectel_dedicated_server_v1.server_tf_acc_test_4", "prolong_periods", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDedicatedServerV1DeletionProtection(t *testing.T) {
+	serverName := acctest.RandomWithPrefix("tf-acc-server-protected")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccSelectelDedicatedServersPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckDedicatedServerV1Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDedicatedServerV1DeletionProtection(serverName, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("selectel_dedicated_server_v1.server_tf_acc_test_5", "deletion_protection", "true"),
+				),
+			},
+			{
+				Config:      testAccDedicatedServerV1DeletionProtection(serverName, true),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile("deletion protection is enabled"),
+			},
+			{
+				ResourceName:      "selectel_dedicated_server_v1.server_tf_acc_test_5",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"root_size", "swap_size", "raid_type", "prolong_periods", "cancel_mode",
+				},
+			},
+			{
+				Config: testAccDedicatedServerV1DeletionProtection(serverName, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("selectel_dedicated_server_v1.server_tf_acc_test_5", "deletion_protection", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestUnitDedicatedServerV1Billing(t *testing.T) {
+	api := fakeservers.New()
+	defer api.Close()
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { testServersFakePreCheck(t) },
+		ProviderFactories: testServersFakeProviderFactories(api),
+		Steps: []resource.TestStep{
+			{
+				Config: testServersFakeProviderBlock + testAccDedicatedServerV1Billing("node-1", false, 0),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("selectel_dedicated_server_v1.server_tf_acc_test_4", "billing_period", "monthly"),
+					resource.TestCheckResourceAttrSet("selectel_dedicated_server_v1.server_tf_acc_test_4", "paid_until"),
+				),
+			},
+			{
+				Config: testServersFakeProviderBlock + testAccDedicatedServerV1Billing("node-1", true, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("selectel_dedicated_server_v1.server_tf_acc_test_4", "auto_renewal", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestDedicatedServerV1LifecycleFake(t *testing.T) {
+	api := fakeservers.New()
+	defer api.Close()
+
+	ctx := context.Background()
+	meta := testServersFakeConfig(t, api)
+	d := schema.TestResourceDataRaw(t, resourceDedicatedServerV1().Schema, map[string]interface{}{
+		"name":                "node-1",
+		"location_id":         1,
+		"root_size":           20,
+		"auto_renewal":        true,
+		"prolong_periods":     2,
+		"deletion_protection": true,
+		"cancel_mode":         servers.ServerCancelModeImmediate,
+	})
+
+	diags := resourceDedicatedServerV1Create(ctx, d, meta)
+	require.False(t, diags.HasError(), diags)
+
+	orders := api.Orders()
+	require.Len(t, orders, 1)
+	assert.Equal(t, "node-1", orders[0]["name"])
+	assert.Equal(t, true, orders[0]["auto_renewal"])
+
+	billing, ok := api.Billing(d.Id())
+	require.True(t, ok)
+	assert.True(t, billing.AutoRenewal)
+	assert.True(t, api.Locked(d.Id()))
+	assert.Equal(t, billing.PaidUntil.Format("2006-01-02T15:04:05Z"), d.Get("paid_until"))
+
+	diags = resourceDedicatedServerV1Delete(ctx, d, meta)
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "deletion protection is enabled")
+
+	_, ok = api.Server(d.Id())
+	assert.True(t, ok)
+}
+
+func TestDedicatedServerV1OrderTargetFake(t *testing.T) {
+	api := fakeservers.New()
+	defer api.Close()
+
+	const (
+		locationUUID = "5c2bd1c4-6a01-4d7a-b94c-4c2c2fbe1d6f"
+		serviceUUID  = "0e6b4d1d-3a43-4b8a-8f3e-1b2f3f0a9a11"
+	)
+	api.AddLocation(fakeservers.Location{UUID: locationUUID, Name: "MSK-2", LocationID: 2})
+	api.AddService(fakeservers.Service{ID: "2", UUID: serviceUUID, Name: "EL50-SSD", Type: "serverchip"})
+	api.SetAvailability(fakeservers.Availability{ServiceUUID: serviceUUID, ConfigID: 2, LocationUUID: locationUUID, Quantity: 1})
+	api.SetAvailability(fakeservers.Availability{ServiceUUID: fakeservers.ServiceUUID, ConfigID: 1, LocationUUID: locationUUID, Quantity: 1})
+
+	ctx := context.Background()
+	meta := testServersFakeConfig(t, api)
+	raw := map[string]interface{}{
+		"name":            "node-1",
+		"location_id":     2,
+		"config_id":       2,
+		"os_id":           fakeservers.OSID,
+		"price_plan_uuid": "be6c2a5f-4160-5145-8695-c628496b208d",
+		"root_size":       20,
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceDedicatedServerV1().Schema, raw)
+	diags := resourceDedicatedServerV1Create(ctx, d, meta)
+	require.False(t, diags.HasError(), diags)
+
+	orders := api.Orders()
+	require.Len(t, orders, 1)
+	assert.Equal(t, locationUUID, orders[0]["location_uuid"])
+	assert.Equal(t, serviceUUID, orders[0]["service_uuid"])
+	assert.Equal(t, float64(2), orders[0]["config_id"])
+	assert.Equal(t, "be6c2a5f-4160-5145-8695-c628496b208d", orders[0]["price_plan_uuid"])
+	assert.Equal(t, float64(fakeservers.OSID), orders[0]["os_id"])
+	assert.Equal(t, "debian", orders[0]["os_template"])
+	assert.Equal(t, "12", orders[0]["version"])
+
+	// Локация предлагает две конфигурации, и без config_id выбрать нельзя
+	delete(raw, "config_id")
+	d = schema.TestResourceDataRaw(t, resourceDedicatedServerV1().Schema, raw)
+	diags = resourceDedicatedServerV1Create(ctx, d, meta)
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "set config_id")
+
+	raw["location_id"] = 3
+	d = schema.TestResourceDataRaw(t, resourceDedicatedServerV1().Schema, raw)
+	diags = resourceDedicatedServerV1Create(ctx, d, meta)
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "location 3 is not found")
+	assert.Len(t, api.Orders(), 1)
+}
+
+func TestDedicatedServerV1PowerStateFake(t *testing.T) {
+	testDedicatedServerV1PowerStateDelay(t)
+
+	api := fakeservers.New()
+	defer api.Close()
+
+	ctx := context.Background()
+	meta := testServersFakeConfig(t, api)
+	raw := func(powerState string) map[string]interface{} {
+		return map[string]interface{}{
+			"name":        "node-1",
+			"location_id": 1,
+			"root_size":   20,
+			"power_state": powerState,
+		}
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceDedicatedServerV1().Schema, raw(servers.ServerPowerStateOff))
+	diags := resourceDedicatedServerV1Create(ctx, d, meta)
+	require.False(t, diags.HasError(), diags)
+	assert.Len(t, d.Id(), 36)
+
+	server, ok := api.Server(d.Id())
+	require.True(t, ok)
+	assert.Equal(t, servers.ServerStatusStopped, server.Status)
+	assert.Equal(t, servers.ServerPowerStateOff, d.Get("power_state"))
+	assert.Equal(t, servers.ServerStatusStopped, d.Get("status"))
+
+	_, next, err := testServersFakePlan(ctx, t, resourceDedicatedServerV1(), d, meta, raw(servers.ServerPowerStateOn))
+	require.NoError(t, err)
+	diags = resourceDedicatedServerV1Update(ctx, next, meta)
+	require.False(t, diags.HasError(), diags)
+
+	server, ok = api.Server(d.Id())
+	require.True(t, ok)
+	assert.Equal(t, servers.ServerStatusActive, server.Status)
+	assert.Equal(t, servers.ServerPowerStateOn, next.Get("power_state"))
+	assert.Equal(t, servers.ServerStatusActive, next.Get("status"))
+
+	// Сервер выключили вне Terraform
+	api.SetServerStatus(d.Id(), servers.ServerStatusStopped)
+	diags = resourceDedicatedServerV1Read(ctx, next, meta)
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, servers.ServerPowerStateOff, next.Get("power_state"))
+}
+
+func TestDedicatedServerV1ReadRemovedOutOfBandFake(t *testing.T) {
+	api := fakeservers.New()
+	defer api.Close()
+
+	ctx := context.Background()
+	meta := testServersFakeConfig(t, api)
+
+	ordered := schema.TestResourceDataRaw(t, resourceDedicatedServerV1().Schema, map[string]interface{}{
+		"name":        "node-1",
+		"location_id": 1,
+		"root_size":   20,
+	})
+	require.False(t, resourceDedicatedServerV1Create(ctx, ordered, meta).HasError())
+
+	legacy := api.AddServer(fakeservers.Server{Name: "node-2", Status: servers.ServerStatusActive})
+	decommissioned := schema.TestResourceDataRaw(t, resourceDedicatedServerV1().Schema, map[string]interface{}{})
+	decommissioned.SetId(strconv.Itoa(legacy.ID))
+
+	api.RemoveServer(ordered.Id())
+	api.SetServerStatus(strconv.Itoa(legacy.ID), servers.ServerStatusDecommissioned)
+
+	for _, d := range []*schema.ResourceData{ordered, decommissioned} {
+		diags := resourceDedicatedServerV1Read(ctx, d, meta)
+		require.Len(t, diags, 1)
+		assert.Equal(t, diag.Warning, diags[0].Severity)
+		assert.Empty(t, d.Id())
+	}
+}
+
+func TestDedicatedServerV1LegacyDeletionProtectionFake(t *testing.T) {
+	api := fakeservers.New()
+	defer api.Close()
+
+	ctx := context.Background()
+	meta := testServersFakeConfig(t, api)
+	raw := map[string]interface{}{"name": "node-2", "location_id": 1, "root_size": 20}
+	ordered := schema.TestResourceDataRaw(t, resourceDedicatedServerV1().Schema, raw)
+	require.False(t, resourceDedicatedServerV1Create(ctx, ordered, meta).HasError())
+	legacy, ok := api.Server(ordered.Id())
+	require.True(t, ok)
+
+	// Сервер, импортированный по числовому ID
+	imported := schema.TestResourceDataRaw(t, resourceDedicatedServerV1().Schema, raw)
+	imported.SetId(strconv.Itoa(legacy.ID))
+
+	raw["deletion_protection"] = true
+	_, d, err := testServersFakePlan(ctx, t, resourceDedicatedServerV1(), imported, meta, raw)
+	require.NoError(t, err)
+
+	diags := resourceDedicatedServerV1Update(ctx, d, meta)
+	require.False(t, diags.HasError(), diags)
+	assert.True(t, api.Locked(legacy.UUID))
+
+	// Блокировка, снятая вне Terraform, читается по числовому ID
+	serversService, err := meta.GetServersService()
+	require.NoError(t, err)
+	require.NoError(t, serversService.SetServerLock(ctx, legacy.UUID, &servers.ServerLock{Locked: false}))
+
+	diags = resourceDedicatedServerV1Read(ctx, d, meta)
+	require.False(t, diags.HasError(), diags)
+	assert.False(t, d.Get("deletion_protection").(bool))
+}
+
+func TestDedicatedServerV1ImportedUUIDPowerStateFake(t *testing.T) {
+	testDedicatedServerV1PowerStateDelay(t)
+
+	api := fakeservers.New()
+	defer api.Close()
+
+	ctx := context.Background()
+	meta := testServersFakeConfig(t, api)
+	raw := map[string]interface{}{"name": "node-1", "location_id": 1, "root_size": 20}
+	ordered := schema.TestResourceDataRaw(t, resourceDedicatedServerV1().Schema, raw)
+	require.False(t, resourceDedicatedServerV1Create(ctx, ordered, meta).HasError())
+	server, ok := api.Server(ordered.Id())
+	require.True(t, ok)
+
+	// У импортированного по UUID сервера числовой ID находится по списку
+	d := schema.TestResourceDataRaw(t, resourceDedicatedServerV1().Schema, raw)
+	d.SetId(ordered.Id())
+	diags := resourceDedicatedServerV1Read(ctx, d, meta)
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, server.ID, d.Get("server_id"))
+	assert.Equal(t, servers.ServerStatusActive, d.Get("status"))
+	assert.Equal(t, servers.ServerPowerStateOn, d.Get("power_state"))
+
+	raw["power_state"] = servers.ServerPowerStateOff
+	_, next, err := testServersFakePlan(ctx, t, resourceDedicatedServerV1(), d, meta, raw)
+	require.NoError(t, err)
+	diags = resourceDedicatedServerV1Update(ctx, next, meta)
+	require.False(t, diags.HasError(), diags)
+
+	server, ok = api.Server(d.Id())
+	require.True(t, ok)
+	assert.Equal(t, servers.ServerStatusStopped, server.Status)
+}
+
+func TestDedicatedServerV1LegacyBillingFake(t *testing.T) {
+	api := fakeservers.New()
+	defer api.Close()
+
+	ctx := context.Background()
+	meta := testServersFakeConfig(t, api)
+	raw := map[string]interface{}{"name": "node-2", "location_id": 1, "root_size": 20}
+	ordered := schema.TestResourceDataRaw(t, resourceDedicatedServerV1().Schema, raw)
+	require.False(t, resourceDedicatedServerV1Create(ctx, ordered, meta).HasError())
+	legacy, ok := api.Server(ordered.Id())
+	require.True(t, ok)
+	billing, ok := api.Billing(legacy.UUID)
+	require.True(t, ok)
+
+	// Сервер, импортированный по числовому ID, читает аренду по UUID аренды
+	imported := schema.TestResourceDataRaw(t, resourceDedicatedServerV1().Schema, raw)
+	imported.SetId(strconv.Itoa(legacy.ID))
+	require.False(t, resourceDedicatedServerV1Read(ctx, imported, meta).HasError())
+	assert.Equal(t, billing.Period, imported.Get("billing_period"))
+	assert.Equal(t, billing.PaidUntil.Format("2006-01-02T15:04:05Z"), imported.Get("paid_until"))
+
+	raw["auto_renewal"] = true
+	raw["prolong_periods"] = 2
+	_, d, err := testServersFakePlan(ctx, t, resourceDedicatedServerV1(), imported, meta, raw)
+	require.NoError(t, err)
+	diags := resourceDedicatedServerV1Update(ctx, d, meta)
+	require.False(t, diags.HasError(), diags)
+
+	prolonged, ok := api.Billing(legacy.UUID)
+	require.True(t, ok)
+	assert.True(t, prolonged.AutoRenewal)
+	assert.Equal(t, billing.PaidUntil.AddDate(0, 2, 0), *prolonged.PaidUntil)
+
+	// Удаление снимает сервер с аренды с учетом cancel_mode, а не удаляет его
+	diags = resourceDedicatedServerV1Delete(ctx, d, meta)
+	require.False(t, diags.HasError(), diags)
+	cancelled, ok := api.Billing(legacy.UUID)
+	require.True(t, ok)
+	assert.True(t, cancelled.CancelAtPeriodEnd)
+	_, ok = api.Server(legacy.UUID)
+	assert.True(t, ok)
+}
+
+func TestDedicatedServerV1ProlongPeriodsDecreaseFake(t *testing.T) {
+	api := fakeservers.New()
+	defer api.Close()
+
+	ctx := context.Background()
+	meta := testServersFakeConfig(t, api)
+	raw := map[string]interface{}{"name": "node-1", "location_id": 1, "root_size": 20, "prolong_periods": 3}
+	d := schema.TestResourceDataRaw(t, resourceDedicatedServerV1().Schema, raw)
+	require.False(t, resourceDedicatedServerV1Create(ctx, d, meta).HasError())
+	billing, ok := api.Billing(d.Id())
+	require.True(t, ok)
+
+	// Уменьшение отклоняется, поэтому 3 -> 1 -> 3 не оплачивает два периода
+	// повторно
+	raw["prolong_periods"] = 1
+	_, _, err := testServersFakePlan(ctx, t, resourceDedicatedServerV1(), d, meta, raw)
+	assert.ErrorContains(t, err, "prolong_periods can't be decreased from 3 to 1")
+
+	raw["prolong_periods"] = 3
+	diff, _, err := testServersFakePlan(ctx, t, resourceDedicatedServerV1(), d, meta, raw)
+	require.NoError(t, err)
+	require.NotNil(t, diff)
+	assert.NotContains(t, diff.Attributes, "prolong_periods")
+
+	raw["prolong_periods"] = 4
+	_, next, err := testServersFakePlan(ctx, t, resourceDedicatedServerV1(), d, meta, raw)
+	require.NoError(t, err)
+	diags := resourceDedicatedServerV1Update(ctx, next, meta)
+	require.False(t, diags.HasError(), diags)
+
+	prolonged, ok := api.Billing(d.Id())
+	require.True(t, ok)
+	assert.Equal(t, billing.PaidUntil.AddDate(0, 1, 0), *prolonged.PaidUntil)
+}
+
+func TestServerPowerStateFromStatus(t *testing.T) {
+	powerState, ok := serverPowerStateFromStatus(servers.ServerStatusActive)
+	assert.True(t, ok)
+	assert.Equal(t, servers.ServerPowerStateOn, powerState)
+
+	powerState, ok = serverPowerStateFromStatus(servers.ServerStatusStopped)
+	assert.True(t, ok)
+	assert.Equal(t, servers.ServerPowerStateOff, powerState)
+
+	for _, status := range []string{servers.ServerStatusRebooting, servers.ServerStatusInstalling, servers.ServerStatusMaintenance} {
+		_, ok := serverPowerStateFromStatus(status)
+		assert.False(t, ok, status)
+	}
+}
+
+func testDedicatedServerV1PowerStateDelay(t *testing.T) {
+	delay := dedicatedServerV1PowerStateDelay
+	dedicatedServerV1PowerStateDelay = 10 * time.Millisecond
+	t.Cleanup(func() { dedicatedServerV1PowerStateDelay = delay })
+}
+
 func testAccSelectelDedicatedServersPreCheck(t *testing.T) {
 	testAccSelectelPreCheck(t)
 
@@ -133,7 +588,7 @@ func testAccCheckDedicatedServerV1Destroy(s *terraform.State) error {
 	return nil
 }
 
-func testAccCheckDedicatedServerV1Exists(n string, server *DedicatedServer) resource.TestCheckFunc {
+func testAccCheckDedicatedServerV1Exists(n string, server *servers.DedicatedServer) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
 		if !ok {
@@ -228,3 +683,53 @@ resource "selectel_dedicated_server_v1" "server_tf_acc_test_2" {
   }
 }`, name)
 }
+
+func testAccDedicatedServerV1PowerState(name, powerState string) string {
+	return fmt.Sprintf(`
+resource "selectel_dedicated_server_v1" "server_tf_acc_test_3" {
+  name        = "%s"
+  location_id = 1
+  root_size   = 20
+  power_state = "%s"
+
+  timeouts {
+    create = "60m"
+    update = "30m"
+    delete = "30m"
+  }
+}`, name, powerState)
+}
+
+func testAccDedicatedServerV1Billing(name string, autoRenewal bool, prolongPeriods int) string {
+	return fmt.Sprintf(`
+resource "selectel_dedicated_server_v1" "server_tf_acc_test_4" {
+  name            = "%s"
+  location_id     = 1
+  root_size       = 20
+  billing_period  = "monthly"
+  auto_renewal    = %t
+  prolong_periods = %d
+  cancel_mode     = "immediate"
+
+  timeouts {
+    create = "60m"
+    delete = "30m"
+  }
+}`, name, autoRenewal, prolongPeriods)
+}
+
+func testAccDedicatedServerV1DeletionProtection(name string, deletionProtection bool) string {
+	return fmt.Sprintf(`
+resource "selectel_dedicated_server_v1" "server_tf_acc_test_5" {
+  name                = "%s"
+  location_id         = 1
+  root_size           = 20
+  deletion_protection = %t
+  cancel_mode         = "immediate"
+
+  timeouts {
+    create = "60m"
+    delete = "30m"
+  }
+}`, name, deletionProtection)
+}
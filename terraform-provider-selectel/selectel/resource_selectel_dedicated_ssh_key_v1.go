@@ -0,0 +1,155 @@
+package selectel
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/servers"
+)
+
+func resourceDedicatedSSHKeyV1() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDedicatedSSHKeyV1Create,
+		ReadContext:   resourceDedicatedSSHKeyV1Read,
+		UpdateContext: resourceDedicatedSSHKeyV1Update,
+		DeleteContext: resourceDedicatedSSHKeyV1Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringLenBetween(1, 255),
+				Description:  "Name of the SSH key. Servers can reference the key by this name",
+			},
+			"public_key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validateServerSSHPublicKey,
+				StateFunc:    normalizeServerSSHPublicKey,
+				Description:  "Public key in the authorized_keys format",
+			},
+			"fingerprint": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "SHA256 fingerprint of the public key",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "SSH key creation timestamp",
+			},
+		},
+	}
+}
+
+func resourceDedicatedSSHKeyV1Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	serversService, err := config.GetServersService()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	createOpts := &servers.ServerSSHKeyCreate{
+		Name:      d.Get("name").(string),
+		PublicKey: normalizeServerSSHPublicKey(d.Get("public_key")),
+	}
+
+	log.Print(msgCreate(objectServerSSHKey, createOpts.Name))
+	key, err := serversService.CreateSSHKey(ctx, createOpts)
+	if err != nil {
+		return diag.FromErr(errCreatingObject(objectServerSSHKey, err))
+	}
+	if key == nil || key.UUID == "" {
+		return diag.FromErr(errReadFromResponse(objectServerSSHKey))
+	}
+
+	d.SetId(key.UUID)
+
+	return resourceDedicatedSSHKeyV1Read(ctx, d, meta)
+}
+
+func resourceDedicatedSSHKeyV1Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	serversService, err := config.GetServersService()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Print(msgGet(objectServerSSHKey, d.Id()))
+	key, err := serversService.GetSSHKey(ctx, d.Id())
+	if err != nil {
+		if errors.Is(err, servers.ErrNotFound) {
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(errGettingObject(objectServerSSHKey, d.Id(), err))
+	}
+
+	fingerprint := key.Fingerprint
+	if fingerprint == "" {
+		// Не все ответы API содержат отпечаток, вычисляем его сами.
+		fingerprint, err = serverSSHKeyFingerprint(key.PublicKey)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.Set("name", key.Name)
+	d.Set("public_key", key.PublicKey)
+	d.Set("fingerprint", fingerprint)
+	if key.CreatedAt != nil {
+		d.Set("created_at", key.CreatedAt.Format("2006-01-02T15:04:05Z"))
+	}
+
+	return nil
+}
+
+func resourceDedicatedSSHKeyV1Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	serversService, err := config.GetServersService()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	updateOpts := &servers.ServerSSHKeyUpdate{}
+
+	if d.HasChange("name") {
+		name := d.Get("name").(string)
+		updateOpts.Name = &name
+	}
+
+	if d.HasChange("public_key") {
+		publicKey := normalizeServerSSHPublicKey(d.Get("public_key"))
+		updateOpts.PublicKey = &publicKey
+	}
+
+	log.Print(msgUpdate(objectServerSSHKey, d.Id(), d.Get("name")))
+	_, err = serversService.UpdateSSHKey(ctx, d.Id(), updateOpts)
+	if err != nil {
+		return diag.FromErr(errUpdatingObject(objectServerSSHKey, d.Id(), err))
+	}
+
+	return resourceDedicatedSSHKeyV1Read(ctx, d, meta)
+}
+
+func resourceDedicatedSSHKeyV1Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*Config)
+	serversService, err := config.GetServersService()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Print(msgDelete(objectServerSSHKey, d.Id()))
+	err = serversService.DeleteSSHKey(ctx, d.Id())
+	if err != nil {
+		return diag.FromErr(errDeletingObject(objectServerSSHKey, d.Id(), err))
+	}
+
+	return nil
+}
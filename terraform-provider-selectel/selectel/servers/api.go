@@ -0,0 +1,61 @@
+package servers
+
+import "context"
+
+// ServersAPI описывает методы API выделенных серверов.
+// Ресурсы провайдера зависят от этого интерфейса, а не от ServersService,
+// что позволяет подменять реализацию в тестах и переиспользовать клиент.
+type ServersAPI interface {
+	// Серверы
+	ListServers(ctx context.Context, opts *ServersListOptions) ([]*DedicatedServer, error)
+	GetServer(ctx context.Context, serverID int) (*DedicatedServer, error)
+	CreateServer(ctx context.Context, createOpts *DedicatedServerCreate) (*DedicatedServer, error)
+	UpdateServer(ctx context.Context, serverID int, updateOpts *DedicatedServerUpdate) (*DedicatedServer, error)
+	DeleteServer(ctx context.Context, serverID int) error
+	GetServerHardware(ctx context.Context, serverID string) (*ServerHardware, error)
+	GetServerTraffic(ctx context.Context, serverID string, opts *ServerTrafficOpts) (*ServerTraffic, error)
+
+	// Действия и задачи
+	ServerAction(ctx context.Context, serverID int, action *DedicatedServerAction) (*ServerTaskStatus, error)
+	StartServer(ctx context.Context, serverID int) (*ServerTaskStatus, error)
+	StopServer(ctx context.Context, serverID int) (*ServerTaskStatus, error)
+	RestartServer(ctx context.Context, serverID int) (*ServerTaskStatus, error)
+	ReinstallServer(ctx context.Context, serverID int, reinstallOpts *ServerReinstallOpts) (*ServerTaskStatus, error)
+	PowerCycleServer(ctx context.Context, serverID int) (*ServerTaskStatus, error)
+	GetTask(ctx context.Context, taskID int) (*ServerTaskStatus, error)
+	WaitForTask(ctx context.Context, taskID int) (*ServerTaskStatus, error)
+
+	// Справочники
+	ListConfigurations(ctx context.Context) ([]*ServerConfiguration, error)
+	GetConfiguration(ctx context.Context, configID int) (*ServerConfiguration, error)
+	ListLocations(ctx context.Context) ([]*ServerLocation, error)
+	ListOperatingSystems(ctx context.Context) ([]*ServerOS, error)
+	ListOperatingSystemsNew(ctx context.Context, opts *OperatingSystemsListOpts) ([]*ServerOS, error)
+	GetServices(ctx context.Context) ([]*ServerService, error)
+	ListServerAvailability(ctx context.Context, opts *ServerAvailabilityListOpts) ([]*ServerAvailability, error)
+
+	// Пользовательские шаблоны установки ОС
+	ListOSTemplates(ctx context.Context) ([]*ServerOSTemplate, error)
+	GetOSTemplate(ctx context.Context, templateUUID string) (*ServerOSTemplate, error)
+	CreateOSTemplate(ctx context.Context, createOpts *ServerOSTemplateCreate) (*ServerOSTemplate, error)
+	UpdateOSTemplate(ctx context.Context, templateUUID string, updateOpts *ServerOSTemplateUpdate) (*ServerOSTemplate, error)
+	DeleteOSTemplate(ctx context.Context, templateUUID string) error
+
+	// Биллинг
+	CreateServerResource(ctx context.Context, createOpts *DedicatedServerCreateBilling) (*DedicatedServerCreateResponse, error)
+	GetServerBilling(ctx context.Context, serverUUID string) (*ServerBilling, error)
+	UpdateServerBilling(ctx context.Context, serverUUID string, updateOpts *ServerBillingUpdate) (*ServerBilling, error)
+	ProlongServer(ctx context.Context, serverUUID string, prolongOpts *ServerBillingProlong) (*ServerBilling, error)
+	CancelServerResource(ctx context.Context, serverUUID string, cancelOpts *ServerCancelOpts) error
+	GetServerLock(ctx context.Context, serverUUID string) (*ServerLock, error)
+	SetServerLock(ctx context.Context, serverUUID string, lockOpts *ServerLock) error
+	GetServerLabels(ctx context.Context, serverUUID string) (*ServerLabels, error)
+	SetServerLabels(ctx context.Context, serverUUID string, labelsOpts *ServerLabels) error
+
+	// SSH ключи
+	ListSSHKeys(ctx context.Context) ([]*ServerSSHKey, error)
+	GetSSHKey(ctx context.Context, keyUUID string) (*ServerSSHKey, error)
+	CreateSSHKey(ctx context.Context, createOpts *ServerSSHKeyCreate) (*ServerSSHKey, error)
+	UpdateSSHKey(ctx context.Context, keyUUID string, updateOpts *ServerSSHKeyUpdate) (*ServerSSHKey, error)
+	DeleteSSHKey(ctx context.Context, keyUUID string) error
+}
@@ -0,0 +1,367 @@
+package servers
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ServersClient представляет клиент для работы с API выделенных серверов Selectel
+type ServersClient struct {
+	HTTPClient  *http.Client
+	TokenSource TokenSource
+	BaseURL     string
+	UserAgent   string
+
+	// AuthDescription описывает, чьим токеном выполняются запросы. Попадает
+	// в ошибки 401 и 403, чтобы было видно, чьи права проверять
+	AuthDescription string
+}
+
+// DefaultBaseURL — адрес API выделенных серверов по умолчанию
+const DefaultBaseURL = "https://api.selectel.ru/servers/v2/"
+
+// DefaultRequestTimeout ограничивает один запрос к API по умолчанию
+const DefaultRequestTimeout = 30 * time.Second
+
+// ServersClientOptions содержит опции для создания клиента серверов
+type ServersClientOptions struct {
+	Token string
+
+	// TokenSource выдает и обновляет токены. Если не задан, все запросы
+	// используют Token
+	TokenSource TokenSource
+
+	// AuthDescription описывает владельца токена для ошибок авторизации,
+	// например «service user ci, project-scoped token»
+	AuthDescription string
+
+	BaseURL    string
+	UserAgent  string
+	HTTPClient *http.Client
+
+	// ProxyURL направляет запросы через прокси. Пустой адрес берет прокси
+	// из HTTPS_PROXY, HTTP_PROXY и NO_PROXY
+	ProxyURL string
+
+	// CACertFile — PEM-файл с сертификатами, которым доверяем вместе с
+	// системными
+	CACertFile string
+
+	// Insecure отключает проверку TLS-сертификата API
+	Insecure bool
+
+	// Timeout ограничивает один запрос, по умолчанию DefaultRequestTimeout
+	Timeout time.Duration
+}
+
+// NewServersClient создает новый экземпляр клиента для работы с выделенными серверами
+func NewServersClient(options *ServersClientOptions) (*ServersClient, error) {
+	if options == nil {
+		return nil, fmt.Errorf("client options cannot be nil")
+	}
+
+	tokenSource := options.TokenSource
+	if tokenSource == nil {
+		if options.Token == "" {
+			return nil, fmt.Errorf("token is required")
+		}
+		tokenSource = StaticTokenSource(options.Token)
+	}
+
+	client := &ServersClient{
+		TokenSource:     tokenSource,
+		AuthDescription: options.AuthDescription,
+	}
+
+	// Устанавливаем базовый URL. Пути запросов дописываются к нему, поэтому
+	// он должен заканчиваться слешем
+	if options.BaseURL != "" {
+		client.BaseURL = strings.TrimSuffix(options.BaseURL, "/") + "/"
+	} else {
+		client.BaseURL = DefaultBaseURL
+	}
+
+	// Устанавливаем User-Agent
+	if options.UserAgent != "" {
+		client.UserAgent = options.UserAgent
+	} else {
+		client.UserAgent = "terraform-provider-selectel/1.0"
+	}
+
+	// Устанавливаем HTTP клиент
+	if options.HTTPClient != nil {
+		client.HTTPClient = options.HTTPClient
+	} else {
+		httpClient, err := newServersHTTPClient(options)
+		if err != nil {
+			return nil, err
+		}
+		client.HTTPClient = httpClient
+	}
+
+	return client, nil
+}
+
+// newServersHTTPClient собирает HTTP клиент с прокси, TLS и таймаутом из
+// опций
+func newServersHTTPClient(options *ServersClientOptions) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if options.ProxyURL != "" {
+		proxyURL, err := url.Parse(options.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if options.CACertFile != "" || options.Insecure {
+		tlsConfig := &tls.Config{
+			MinVersion:         tls.VersionTLS12,
+			InsecureSkipVerify: options.Insecure, //nolint:gosec
+		}
+
+		if options.CACertFile != "" {
+			caCerts, err := os.ReadFile(options.CACertFile)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read CA certificate file: %w", err)
+			}
+
+			pool, err := x509.SystemCertPool()
+			if err != nil {
+				pool = x509.NewCertPool()
+			}
+			if !pool.AppendCertsFromPEM(caCerts) {
+				return nil, fmt.Errorf("no PEM certificates found in %s", options.CACertFile)
+			}
+			tlsConfig.RootCAs = pool
+		}
+
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	timeout := options.Timeout
+	if timeout == 0 {
+		timeout = DefaultRequestTimeout
+	}
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+	}, nil
+}
+
+// DoRequest выполняет HTTP запрос к API выделенных серверов
+func (c *ServersClient) DoRequest(ctx context.Context, method, path string, body interface{}) (*http.Response, error) {
+	u, err := url.Parse(c.BaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+
+	// path может содержать строку запроса, которую нельзя класть в u.Path
+	ref, err := url.Parse(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request path: %w", err)
+	}
+
+	u.Path = u.Path + ref.Path
+	u.RawQuery = ref.RawQuery
+
+	log.Printf("[DEBUG] Making %s request to: %s", method, u.String())
+
+	var jsonBody []byte
+	if body != nil {
+		jsonBody, err = json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		// Тело может содержать user_data, SSH ключи и шаблоны установки,
+		// поэтому в лог пишется только его размер
+		log.Printf("[DEBUG] Request body: %d bytes", len(jsonBody))
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.doAuthorizedRequest(ctx, method, u.String(), jsonBody)
+		if err != nil || resp.StatusCode != http.StatusTooManyRequests || attempt >= rateLimitRetries {
+			return resp, err
+		}
+
+		// 429 означает, что API не приняло запрос, поэтому его можно
+		// повторить для любого метода
+		delay := rateLimitRetryDelay(resp, attempt)
+		resp.Body.Close()
+
+		log.Printf("[DEBUG] Servers API rate limit exceeded, retrying %s %s in %s", method, u.String(), delay)
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// rateLimitRetries — сколько раз повторяется запрос, отклоненный из-за
+// превышения лимита запросов
+const rateLimitRetries = 3
+
+// rateLimitBaseDelay — пауза перед первым повтором, если API не вернуло
+// Retry-After. Каждый следующий повтор ждет вдвое дольше
+var rateLimitBaseDelay = time.Second
+
+// rateLimitMaxDelay ограничивает паузу из Retry-After
+const rateLimitMaxDelay = time.Minute
+
+// rateLimitRetryDelay возвращает паузу перед повтором запроса: из заголовка
+// Retry-After в секундах или экспоненциальную
+func rateLimitRetryDelay(resp *http.Response, attempt int) time.Duration {
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+		return min(time.Duration(seconds)*time.Second, rateLimitMaxDelay)
+	}
+
+	return rateLimitBaseDelay << attempt
+}
+
+// doAuthorizedRequest выполняет запрос с токеном из TokenSource. Если API
+// отклонило токен, а источник выдает новый, запрос повторяется один раз
+func (c *ServersClient) doAuthorizedRequest(ctx context.Context, method, requestURL string, jsonBody []byte) (*http.Response, error) {
+	token, err := c.TokenSource.Token(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get servers API token: %w", err)
+	}
+
+	resp, err := c.doRequest(ctx, method, requestURL, jsonBody, token)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	// Токен истек или отозван: получаем новый и повторяем запрос один раз
+	c.TokenSource.Invalidate(token)
+	newToken, err := c.TokenSource.Token(ctx)
+	if err != nil || newToken == token {
+		if err != nil {
+			log.Printf("[WARN] Failed to refresh servers API token after 401: %s", err)
+		}
+		return resp, nil
+	}
+	resp.Body.Close()
+
+	log.Printf("[DEBUG] Servers API rejected the token, retrying %s %s with a new one", method, requestURL)
+
+	return c.doRequest(ctx, method, requestURL, jsonBody, newToken)
+}
+
+func (c *ServersClient) doRequest(ctx context.Context, method, requestURL string, jsonBody []byte, token string) (*http.Response, error) {
+	var reqBody io.Reader
+	if jsonBody != nil {
+		reqBody = bytes.NewReader(jsonBody)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.UserAgent)
+	req.Header.Set("X-Auth-Token", token)
+
+	log.Printf("[DEBUG] Using token (length: %d)", len(token))
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+
+	log.Printf("[DEBUG] Response status: %d", resp.StatusCode)
+
+	return resp, nil
+}
+
+// ParseResponse парсит ответ от API
+func (c *ServersClient) ParseResponse(resp *http.Response, result interface{}) error {
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		apiError := newServersAPIError(resp, body)
+		if c.AuthDescription != "" && (errors.Is(apiError, ErrUnauthorized) || errors.Is(apiError, ErrForbidden)) {
+			return fmt.Errorf("dedicated servers API rejected the token of %s, check its roles and scope: %w", c.AuthDescription, apiError)
+		}
+
+		return apiError
+	}
+
+	if result != nil {
+		if err := json.Unmarshal(body, result); err != nil {
+			return fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ServersListOptions содержит опции для запросов списка серверов
+type ServersListOptions struct {
+	Page     int    `url:"page,omitempty"`
+	PerPage  int    `url:"per_page,omitempty"`
+	Sort     string `url:"sort,omitempty"`
+	Status   string `url:"status,omitempty"`
+	Location string `url:"location,omitempty"`
+
+	// LabelSelector отбирает серверы по меткам, например "env=prod,role!=db"
+	LabelSelector string `url:"label_selector,omitempty"`
+}
+
+// BuildQueryString строит строку запроса из опций
+func (opts *ServersListOptions) BuildQueryString() string {
+	values := url.Values{}
+
+	if opts.Page > 0 {
+		values.Add("page", strconv.Itoa(opts.Page))
+	}
+
+	if opts.PerPage > 0 {
+		values.Add("per_page", strconv.Itoa(opts.PerPage))
+	}
+
+	if opts.Sort != "" {
+		values.Add("sort", opts.Sort)
+	}
+
+	if opts.Status != "" {
+		values.Add("status", opts.Status)
+	}
+
+	if opts.Location != "" {
+		values.Add("location", opts.Location)
+	}
+
+	if opts.LabelSelector != "" {
+		values.Add("label_selector", opts.LabelSelector)
+	}
+
+	if len(values) > 0 {
+		return "?" + values.Encode()
+	}
+
+	return ""
+}
@@ -0,0 +1,223 @@
+package servers
+
+import (
+	"bytes"
+	"context"
+	"encoding/pem"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewServersClientDefaults(t *testing.T) {
+	client, err := NewServersClient(&ServersClientOptions{Token: "token"})
+	require.NoError(t, err)
+
+	assert.Equal(t, DefaultBaseURL, client.BaseURL)
+	assert.Equal(t, DefaultRequestTimeout, client.HTTPClient.Timeout)
+
+	client, err = NewServersClient(&ServersClientOptions{
+		Token:   "token",
+		BaseURL: "https://staging.example.com/servers/v2",
+		Timeout: 5 * time.Second,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "https://staging.example.com/servers/v2/", client.BaseURL)
+	assert.Equal(t, 5*time.Second, client.HTTPClient.Timeout)
+}
+
+func TestNewServersClientProxy(t *testing.T) {
+	var gotURL string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotURL = r.URL.String()
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer proxy.Close()
+
+	client, err := NewServersClient(&ServersClientOptions{
+		Token:    "token",
+		BaseURL:  "http://servers.example.com/servers/v2/",
+		ProxyURL: proxy.URL,
+	})
+	require.NoError(t, err)
+
+	resp, err := client.DoRequest(context.Background(), http.MethodGet, "location", nil)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, "http://servers.example.com/servers/v2/location", gotURL)
+}
+
+func TestNewServersClientTLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	caCertFile := filepath.Join(t.TempDir(), "ca.pem")
+	caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	require.NoError(t, os.WriteFile(caCertFile, caCert, 0o600))
+
+	testCases := []struct {
+		name    string
+		options ServersClientOptions
+		valid   bool
+	}{
+		{"system roots", ServersClientOptions{}, false},
+		{"ca cert file", ServersClientOptions{CACertFile: caCertFile}, true},
+		{"insecure", ServersClientOptions{Insecure: true}, true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			options := testCase.options
+			options.Token = "token"
+			options.BaseURL = server.URL + "/servers/v2/"
+
+			client, err := NewServersClient(&options)
+			require.NoError(t, err)
+
+			resp, err := client.DoRequest(context.Background(), http.MethodGet, "location", nil)
+			if !testCase.valid {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			resp.Body.Close()
+			assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		})
+	}
+}
+
+func TestNewServersClientInvalidOptions(t *testing.T) {
+	notPEM := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(notPEM, []byte("not a certificate"), 0o600))
+
+	_, err := NewServersClient(&ServersClientOptions{Token: "token", CACertFile: notPEM})
+	assert.ErrorContains(t, err, "no PEM certificates")
+
+	_, err = NewServersClient(&ServersClientOptions{Token: "token", CACertFile: filepath.Join(t.TempDir(), "missing.pem")})
+	assert.ErrorContains(t, err, "failed to read CA certificate file")
+
+	_, err = NewServersClient(&ServersClientOptions{Token: "token", ProxyURL: "://proxy"})
+	assert.ErrorContains(t, err, "invalid proxy URL")
+}
+
+func TestParseResponseAuthDescription(t *testing.T) {
+	status := http.StatusForbidden
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"message": "access denied"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewServersClient(&ServersClientOptions{
+		Token:           "token",
+		BaseURL:         server.URL,
+		AuthDescription: "service user ci (project-scoped token for project p1)",
+	})
+	require.NoError(t, err)
+
+	for _, expected := range []error{ErrForbidden, ErrUnauthorized} {
+		if expected == ErrUnauthorized {
+			status = http.StatusUnauthorized
+		}
+
+		resp, err := client.DoRequest(context.Background(), http.MethodGet, "location", nil)
+		require.NoError(t, err)
+
+		err = client.ParseResponse(resp, nil)
+		assert.ErrorIs(t, err, expected)
+		assert.ErrorContains(t, err, "rejected the token of service user ci (project-scoped token for project p1)")
+		assert.ErrorContains(t, err, "access denied")
+	}
+
+	status = http.StatusNotFound
+	resp, err := client.DoRequest(context.Background(), http.MethodGet, "location", nil)
+	require.NoError(t, err)
+
+	err = client.ParseResponse(resp, nil)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.NotContains(t, err.Error(), "service user")
+}
+
+func TestDoRequestRateLimited(t *testing.T) {
+	delay := rateLimitBaseDelay
+	rateLimitBaseDelay = time.Millisecond
+	t.Cleanup(func() { rateLimitBaseDelay = delay })
+
+	requests, limited := 0, 2
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		if requests <= limited {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client, err := NewServersClient(&ServersClientOptions{Token: "token", BaseURL: server.URL})
+	require.NoError(t, err)
+
+	resp, err := client.DoRequest(context.Background(), http.MethodPost, "server/1/action", map[string]string{"action": "start"})
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, 3, requests)
+
+	// Лимит не снимается: после всех повторов возвращается ErrRateLimited
+	requests, limited = 0, rateLimitRetries+1
+	resp, err = client.DoRequest(context.Background(), http.MethodGet, "location", nil)
+	require.NoError(t, err)
+	assert.ErrorIs(t, client.ParseResponse(resp, nil), ErrRateLimited)
+	assert.Equal(t, rateLimitRetries+1, requests)
+}
+
+func TestRateLimitRetryDelay(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+	assert.Equal(t, rateLimitBaseDelay, rateLimitRetryDelay(resp, 0))
+	assert.Equal(t, 4*rateLimitBaseDelay, rateLimitRetryDelay(resp, 2))
+
+	resp.Header.Set("Retry-After", "7")
+	assert.Equal(t, 7*time.Second, rateLimitRetryDelay(resp, 2))
+
+	resp.Header.Set("Retry-After", "3600")
+	assert.Equal(t, rateLimitMaxDelay, rateLimitRetryDelay(resp, 0))
+}
+
+func TestCreateServerResourceDoesNotLogSecrets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"result": []}`))
+	}))
+	defer server.Close()
+
+	client, err := NewServersClient(&ServersClientOptions{Token: "token", BaseURL: server.URL})
+	require.NoError(t, err)
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	_, err = NewServersService(client).CreateServerResource(context.Background(), &DedicatedServerCreateBilling{
+		UserHostname: "web-1",
+		SSHKeys:      []string{"ssh-ed25519 AAAAIKey1 user@host"},
+		UserData:     "#cloud-config\npassword: secret",
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, logs.String(), "web-1")
+	assert.NotContains(t, logs.String(), "AAAAIKey1")
+	assert.NotContains(t, logs.String(), "secret")
+}
@@ -0,0 +1,128 @@
+package servers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Классы ошибок API выделенных серверов для проверки через errors.Is
+var (
+	ErrNotFound      = errors.New("servers: not found")
+	ErrConflict      = errors.New("servers: conflict")
+	ErrQuotaExceeded = errors.New("servers: quota exceeded")
+	ErrOutOfStock    = errors.New("servers: out of stock")
+	ErrUnauthorized  = errors.New("servers: unauthorized")
+	ErrForbidden     = errors.New("servers: forbidden")
+	ErrRateLimited   = errors.New("servers: rate limited")
+)
+
+// Коды ошибок, которые API возвращает в поле error_code
+const (
+	ErrorCodeQuotaExceeded = "QUOTA_EXCEEDED"
+	ErrorCodeOutOfStock    = "OUT_OF_STOCK"
+)
+
+// requestIDHeader содержит идентификатор запроса, который нужен поддержке
+const requestIDHeader = "X-Request-Id"
+
+// ServersAPIError представляет ошибку API выделенных серверов
+type ServersAPIError struct {
+	// StatusCode содержит HTTP статус ответа
+	StatusCode int `json:"-"`
+	// RequestID содержит идентификатор запроса из заголовка X-Request-Id
+	RequestID string `json:"-"`
+
+	Code      int    `json:"code"`
+	ErrorCode string `json:"error_code,omitempty"`
+	Message   string `json:"message"`
+	Details   string `json:"details,omitempty"`
+}
+
+// newServersAPIError собирает ошибку из неуспешного ответа. Если тело не
+// удается разобрать, оно целиком попадает в Message, а статус сохраняется.
+func newServersAPIError(resp *http.Response, body []byte) *ServersAPIError {
+	apiError := &ServersAPIError{}
+	if err := json.Unmarshal(body, apiError); err != nil || apiError.Message == "" {
+		apiError.Message = strings.TrimSpace(string(body))
+	}
+	if apiError.Message == "" {
+		apiError.Message = http.StatusText(resp.StatusCode)
+	}
+
+	apiError.StatusCode = resp.StatusCode
+	apiError.RequestID = resp.Header.Get(requestIDHeader)
+	if apiError.Code == 0 {
+		apiError.Code = resp.StatusCode
+	}
+
+	return apiError
+}
+
+// Error реализует интерфейс error
+func (e *ServersAPIError) Error() string {
+	msg := fmt.Sprintf("API error %d: %s", e.Code, e.Message)
+	if e.Details != "" {
+		msg += fmt.Sprintf(" (%s)", e.Details)
+	}
+	if e.RequestID != "" {
+		msg += fmt.Sprintf(" [request_id: %s]", e.RequestID)
+	}
+
+	return msg
+}
+
+// Is позволяет сравнивать ошибку с ErrNotFound, ErrConflict и другими
+// классами через errors.Is
+func (e *ServersAPIError) Is(target error) bool {
+	kind := e.kind()
+	return kind != nil && kind == target
+}
+
+// kind определяет класс ошибки. Код ошибки API точнее HTTP статуса, поэтому
+// проверяется первым.
+func (e *ServersAPIError) kind() error {
+	switch strings.ToUpper(e.ErrorCode) {
+	case ErrorCodeQuotaExceeded:
+		return ErrQuotaExceeded
+	case ErrorCodeOutOfStock:
+		return ErrOutOfStock
+	}
+
+	status := e.StatusCode
+	if status == 0 {
+		status = e.Code
+	}
+
+	switch status {
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusConflict:
+		return ErrConflict
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusForbidden:
+		return ErrForbidden
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	}
+
+	return nil
+}
+
+// IsRetryable сообщает, имеет ли смысл повторить запрос, завершившийся
+// ошибкой err
+func IsRetryable(err error) bool {
+	if errors.Is(err, ErrRateLimited) {
+		return true
+	}
+
+	var apiError *ServersAPIError
+	if errors.As(err, &apiError) {
+		return apiError.StatusCode >= http.StatusInternalServerError
+	}
+
+	return false
+}
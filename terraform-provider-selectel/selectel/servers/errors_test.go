@@ -0,0 +1,59 @@
+package servers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServersAPIErrorIs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		err      *ServersAPIError
+		expected error
+	}{
+		{"not found", &ServersAPIError{StatusCode: http.StatusNotFound}, ErrNotFound},
+		{"conflict", &ServersAPIError{StatusCode: http.StatusConflict}, ErrConflict},
+		{"unauthorized", &ServersAPIError{StatusCode: http.StatusUnauthorized}, ErrUnauthorized},
+		{"forbidden", &ServersAPIError{StatusCode: http.StatusForbidden}, ErrForbidden},
+		{"rate limited", &ServersAPIError{StatusCode: http.StatusTooManyRequests}, ErrRateLimited},
+		{"quota exceeded", &ServersAPIError{StatusCode: http.StatusForbidden, ErrorCode: ErrorCodeQuotaExceeded}, ErrQuotaExceeded},
+		{"out of stock", &ServersAPIError{StatusCode: http.StatusConflict, ErrorCode: "out_of_stock"}, ErrOutOfStock},
+		{"code from body", &ServersAPIError{Code: http.StatusNotFound}, ErrNotFound},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			wrapped := fmt.Errorf("error getting server: %w", testCase.err)
+
+			assert.True(t, errors.Is(wrapped, testCase.expected))
+			for _, other := range []error{ErrNotFound, ErrConflict, ErrUnauthorized, ErrForbidden, ErrRateLimited, ErrQuotaExceeded, ErrOutOfStock} {
+				if other != testCase.expected {
+					assert.False(t, errors.Is(wrapped, other), other)
+				}
+			}
+		})
+	}
+}
+
+func TestServersAPIErrorUndecodableBody(t *testing.T) {
+	service := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-42")
+		w.WriteHeader(http.StatusBadGateway)
+		_, _ = w.Write([]byte("<html>bad gateway</html>"))
+	})
+
+	_, err := service.ListLocations(context.Background())
+
+	var apiErr *ServersAPIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusBadGateway, apiErr.StatusCode)
+	assert.Equal(t, "req-42", apiErr.RequestID)
+	assert.Equal(t, "<html>bad gateway</html>", apiErr.Message)
+	assert.True(t, IsRetryable(err))
+	assert.Contains(t, err.Error(), "request_id: req-42")
+}
@@ -1,4 +1,4 @@
-package selectel
+package servers
 
 import (
 	"time"
@@ -6,7 +6,9 @@ import (
 
 // DedicatedServer представляет выделенный сервер Selectel
 type DedicatedServer struct {
-	ID       int    `json:"id"`
+	ID int `json:"id"`
+	// UUID — идентификатор аренды сервера в биллинге
+	UUID     string `json:"uuid,omitempty"`
 	Name     string `json:"name"`
 	Status   string `json:"status"`
 	StatusHD string `json:"status_hd"`
@@ -33,8 +35,12 @@ type DedicatedServer struct {
 	Price *ServerPrice `json:"price,omitempty"`
 
 	// Дополнительная информация
-	Comment string   `json:"comment,omitempty"`
-	Tags    []string `json:"tags,omitempty"`
+	Comment string            `json:"comment,omitempty"`
+	Tags    []string          `json:"tags,omitempty"`
+	Labels  map[string]string `json:"labels,omitempty"`
+
+	// Установленные SSH ключи
+	SSHKeys []*ServerSSHKey `json:"ssh_keys,omitempty"`
 }
 
 // ServerCPU представляет информацию о процессоре
@@ -95,15 +101,9 @@ type ServerLocation struct {
 	Name        string `json:"name"`
 	LocationID  int    `json:"location_id"`
 	Description string `json:"description"`
+	Region      string `json:"region"`
 	DCCount     *int   `json:"dc_count"`
 	Visibility  string `json:"visibility"`
-
-	// Для совместимости с UI добавляем маппинг на старые поля
-	ID         int    `json:"-"`
-	Code       string `json:"-"`
-	Country    string `json:"-"`
-	City       string `json:"-"`
-	Datacenter string `json:"-"`
 }
 
 // ServerOS представляет операционную систему
@@ -171,6 +171,9 @@ type DedicatedServerUpdate struct {
 	Name    *string  `json:"name,omitempty"`
 	Comment *string  `json:"comment,omitempty"`
 	Tags    []string `json:"tags,omitempty"`
+
+	// Labels заменяет метки сервера целиком, если не nil
+	Labels *map[string]string `json:"labels,omitempty"`
 }
 
 // DedicatedServerAction представляет действие над сервером
@@ -210,6 +213,30 @@ var (
 	ServerStatusMaintenance = "maintenance"
 	ServerStatusError       = "error"
 	ServerStatusStopped     = "stopped"
+
+	// Сервер снят с аренды или выведен из эксплуатации
+	ServerStatusCancelled      = "cancelled"
+	ServerStatusDecommissioned = "decommissioned"
+)
+
+// Константы желаемого состояния питания сервера
+var (
+	ServerPowerStateOn  = "on"
+	ServerPowerStateOff = "off"
+)
+
+// Константы периодов оплаты
+var (
+	ServerBillingPeriodHourly    = "hourly"
+	ServerBillingPeriodMonthly   = "monthly"
+	ServerBillingPeriodQuarterly = "quarterly"
+	ServerBillingPeriodAnnually  = "annually"
+)
+
+// Константы режимов отказа от аренды
+var (
+	ServerCancelModeImmediate = "immediate"
+	ServerCancelModePeriodEnd = "period_end"
 )
 
 // Константы действий над серверами
@@ -242,6 +269,17 @@ type ServerService struct {
 	Region      string `json:"region,omitempty"`
 }
 
+// ServerAvailability представляет наличие серверов услуги в локации
+type ServerAvailability struct {
+	ServiceUUID  string `json:"service_uuid"`
+	ConfigID     int    `json:"config_id,omitempty"`
+	LocationUUID string `json:"location_uuid"`
+	// Quantity — число серверов, доступных для заказа
+	Quantity int `json:"quantity"`
+	// RestockAt — ожидаемая дата поступления, если API ее сообщает
+	RestockAt *time.Time `json:"restock_at,omitempty"`
+}
+
 // DedicatedServerCreateBilling содержит данные для создания сервера через биллинг API
 type DedicatedServerCreateBilling struct {
 	Name         string `json:"name"`
@@ -251,8 +289,9 @@ type DedicatedServerCreateBilling struct {
 	OSID         int    `json:"os_id,omitempty"`
 
 	// Дополнительные опции
-	Comment string   `json:"comment,omitempty"`
-	Tags    []string `json:"tags,omitempty"`
+	Comment string            `json:"comment,omitempty"`
+	Tags    []string          `json:"tags,omitempty"`
+	Labels  map[string]string `json:"labels,omitempty"`
 
 	// SSH ключи
 	SSHKeys []string `json:"ssh_keys,omitempty"`
@@ -271,6 +310,15 @@ type DedicatedServerCreateBilling struct {
 	PayCurrency      string      `json:"pay_currency,omitempty"`
 	UserDesc         string      `json:"user_desc,omitempty"`
 	PartitionsConfig interface{} `json:"partitions_config,omitempty"`
+
+	// CustomTemplateUUID задает пользовательский шаблон установки ОС
+	CustomTemplateUUID string `json:"custom_template_uuid,omitempty"`
+
+	// Quantity — число одинаковых серверов в заказе, по умолчанию один
+	Quantity int `json:"quantity,omitempty"`
+
+	// UserData передается установщику ОС как cloud-init
+	UserData string `json:"user_data,omitempty"`
 }
 
 // DedicatedServerCreateResponse представляет ответ на создание сервера
@@ -299,3 +347,184 @@ type DedicatedServerCreateResult struct {
 	Status  string `json:"status"`
 	OrderID string `json:"order_id,omitempty"`
 }
+
+// ServerSSHKey представляет SSH ключ, зарегистрированный в API серверов
+type ServerSSHKey struct {
+	UUID        string     `json:"uuid"`
+	Name        string     `json:"name"`
+	PublicKey   string     `json:"public_key,omitempty"`
+	Fingerprint string     `json:"fingerprint,omitempty"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+}
+
+// ServerSSHKeyCreate содержит данные для регистрации SSH ключа
+type ServerSSHKeyCreate struct {
+	Name      string `json:"name"`
+	PublicKey string `json:"public_key"`
+}
+
+// ServerSSHKeyUpdate содержит данные для обновления SSH ключа
+type ServerSSHKeyUpdate struct {
+	Name      *string `json:"name,omitempty"`
+	PublicKey *string `json:"public_key,omitempty"`
+}
+
+// Типы файлов автоматической установки пользовательских шаблонов ОС
+var (
+	OSTemplateInstallTypePreseed     = "preseed"
+	OSTemplateInstallTypeKickstart   = "kickstart"
+	OSTemplateInstallTypeAutoinstall = "autoinstall"
+)
+
+// ServerOSTemplate представляет пользовательский шаблон установки ОС
+type ServerOSTemplate struct {
+	UUID        string     `json:"uuid"`
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	BasedOn     string     `json:"based_on"`
+	Version     string     `json:"version,omitempty"`
+	Arch        string     `json:"arch,omitempty"`
+	InstallType string     `json:"install_type"`
+	Content     string     `json:"content"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+}
+
+// ServerOSTemplateCreate содержит данные для создания шаблона установки ОС
+type ServerOSTemplateCreate struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	BasedOn     string `json:"based_on"`
+	Version     string `json:"version,omitempty"`
+	Arch        string `json:"arch,omitempty"`
+	InstallType string `json:"install_type"`
+	Content     string `json:"content"`
+}
+
+// ServerOSTemplateUpdate содержит изменяемые поля шаблона установки ОС
+type ServerOSTemplateUpdate struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+	Content     *string `json:"content,omitempty"`
+}
+
+// ServerHardware представляет аппаратную инвентаризацию сервера
+type ServerHardware struct {
+	Disks    []*ServerDisk   `json:"disks,omitempty"`
+	NICs     []*ServerNIC    `json:"nics,omitempty"`
+	Firmware *ServerFirmware `json:"firmware,omitempty"`
+}
+
+// ServerDisk представляет физический диск сервера
+type ServerDisk struct {
+	Slot     int    `json:"slot"`
+	Serial   string `json:"serial"`
+	Model    string `json:"model"`
+	Type     string `json:"type"`    // "HDD", "SSD", "NVMe"
+	SizeGB   int    `json:"size_gb"` // размер в ГБ
+	Firmware string `json:"firmware,omitempty"`
+}
+
+// ServerNIC представляет сетевой интерфейс сервера
+type ServerNIC struct {
+	Name       string `json:"name"`
+	MACAddress string `json:"mac_address"`
+	Model      string `json:"model,omitempty"`
+	SpeedMbps  int    `json:"speed_mbps"` // скорость линка в Мбит/с
+	LinkState  string `json:"link_state,omitempty"`
+}
+
+// ServerFirmware представляет версии прошивок сервера
+type ServerFirmware struct {
+	BIOSVersion string `json:"bios_version"`
+	BMCVersion  string `json:"bmc_version"`
+}
+
+// ServerBilling представляет биллинговое состояние аренды сервера
+type ServerBilling struct {
+	Status      string     `json:"status,omitempty"`
+	UUID        string     `json:"uuid"`
+	Period      string     `json:"period"`
+	AutoRenewal bool       `json:"auto_renewal"`
+	PaidUntil   *time.Time `json:"paid_until,omitempty"`
+}
+
+// ServerBillingUpdate содержит данные для изменения параметров аренды
+type ServerBillingUpdate struct {
+	Period      *string `json:"period,omitempty"`
+	AutoRenewal *bool   `json:"auto_renewal,omitempty"`
+}
+
+// ServerBillingProlong содержит данные для продления аренды
+type ServerBillingProlong struct {
+	Periods int `json:"periods"`
+}
+
+// ServerReinstallOpts содержит параметры переустановки ОС
+type ServerReinstallOpts struct {
+	OSID    int
+	SSHKeys []string
+
+	// CustomTemplateUUID задает пользовательский шаблон установки ОС
+	CustomTemplateUUID string
+
+	// UserData передается установщику ОС как cloud-init
+	UserData string
+
+	// PartitionsConfig задает новую разметку дисков, пустая сохраняет
+	// текущую
+	PartitionsConfig map[string]interface{}
+}
+
+// OperatingSystemsListOpts содержит параметры запроса списка ОС,
+// доступных для локации и услуги
+type OperatingSystemsListOpts struct {
+	LocationUUID string
+	ServiceUUID  string
+}
+
+// ServerAvailabilityListOpts ограничивает список наличия услугой и
+// локацией, пустые поля не фильтруют
+type ServerAvailabilityListOpts struct {
+	ServiceUUID  string
+	LocationUUID string
+}
+
+// ServerCancelOpts содержит параметры отказа от аренды.
+// Mode принимает значения ServerCancelMode*
+type ServerCancelOpts struct {
+	Mode string
+}
+
+// ServerTrafficOpts содержит параметры запроса трафика.
+// Period задается в формате YYYY-MM, пустое значение означает текущий период
+type ServerTrafficOpts struct {
+	Period string
+}
+
+// ServerLabels содержит метки ключ/значение сервера
+type ServerLabels struct {
+	Labels map[string]string `json:"labels"`
+}
+
+// ServerLock представляет серверную блокировку от отказа от аренды
+type ServerLock struct {
+	Locked bool `json:"locked"`
+}
+
+// ServerTraffic представляет потребление трафика сервером за биллинговый период
+type ServerTraffic struct {
+	PeriodStart   *time.Time           `json:"period_start,omitempty"`
+	PeriodEnd     *time.Time           `json:"period_end,omitempty"`
+	InboundBytes  int64                `json:"inbound_bytes"`
+	OutboundBytes int64                `json:"outbound_bytes"`
+	QuotaBytes    int64                `json:"quota_bytes"` // 0 означает отсутствие квоты
+	Ports         []*ServerPortTraffic `json:"ports,omitempty"`
+}
+
+// ServerPortTraffic представляет потребление трафика отдельным портом
+type ServerPortTraffic struct {
+	Name          string `json:"name"`
+	MACAddress    string `json:"mac_address,omitempty"`
+	InboundBytes  int64  `json:"inbound_bytes"`
+	OutboundBytes int64  `json:"outbound_bytes"`
+}
@@ -0,0 +1,808 @@
+package servers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// ServersService предоставляет методы для работы с выделенными серверами
+type ServersService struct {
+	client *ServersClient
+}
+
+var _ ServersAPI = (*ServersService)(nil)
+
+// NewServersService создает новый сервис для работы с серверами
+func NewServersService(client *ServersClient) *ServersService {
+	return &ServersService{
+		client: client,
+	}
+}
+
+// ListServers возвращает список серверов
+func (s *ServersService) ListServers(ctx context.Context, opts *ServersListOptions) ([]*DedicatedServer, error) {
+	path := "server"
+
+	if opts != nil {
+		path += opts.BuildQueryString()
+	}
+
+	resp, err := s.client.DoRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Data []*DedicatedServer `json:"data"`
+	}
+
+	if err := s.client.ParseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Data, nil
+}
+
+// GetServer возвращает информацию о конкретном сервере
+func (s *ServersService) GetServer(ctx context.Context, serverID int) (*DedicatedServer, error) {
+	path := fmt.Sprintf("server/%d", serverID)
+
+	resp, err := s.client.DoRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Data *DedicatedServer `json:"data"`
+	}
+
+	if err := s.client.ParseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Data, nil
+}
+
+// CreateServer создает новый сервер
+func (s *ServersService) CreateServer(ctx context.Context, createOpts *DedicatedServerCreate) (*DedicatedServer, error) {
+	path := "server"
+
+	resp, err := s.client.DoRequest(ctx, http.MethodPost, path, createOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Data *DedicatedServer `json:"data"`
+	}
+
+	if err := s.client.ParseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Data, nil
+}
+
+// UpdateServer обновляет сервер
+func (s *ServersService) UpdateServer(ctx context.Context, serverID int, updateOpts *DedicatedServerUpdate) (*DedicatedServer, error) {
+	path := fmt.Sprintf("server/%d", serverID)
+
+	resp, err := s.client.DoRequest(ctx, http.MethodPatch, path, updateOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Data *DedicatedServer `json:"data"`
+	}
+
+	if err := s.client.ParseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Data, nil
+}
+
+// DeleteServer удаляет сервер
+func (s *ServersService) DeleteServer(ctx context.Context, serverID int) error {
+	path := fmt.Sprintf("server/%d", serverID)
+
+	resp, err := s.client.DoRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return err
+	}
+
+	return s.client.ParseResponse(resp, nil)
+}
+
+// ServerAction выполняет действие над сервером (start, stop, restart и т.д.)
+func (s *ServersService) ServerAction(ctx context.Context, serverID int, action *DedicatedServerAction) (*ServerTaskStatus, error) {
+	path := fmt.Sprintf("server/%d/action", serverID)
+
+	resp, err := s.client.DoRequest(ctx, http.MethodPost, path, action)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Data *ServerTaskStatus `json:"data"`
+	}
+
+	if err := s.client.ParseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Data, nil
+}
+
+// StartServer запускает сервер
+func (s *ServersService) StartServer(ctx context.Context, serverID int) (*ServerTaskStatus, error) {
+	action := &DedicatedServerAction{
+		Action: ServerActionStart,
+	}
+	return s.ServerAction(ctx, serverID, action)
+}
+
+// StopServer останавливает сервер
+func (s *ServersService) StopServer(ctx context.Context, serverID int) (*ServerTaskStatus, error) {
+	action := &DedicatedServerAction{
+		Action: ServerActionStop,
+	}
+	return s.ServerAction(ctx, serverID, action)
+}
+
+// RestartServer перезапускает сервер
+func (s *ServersService) RestartServer(ctx context.Context, serverID int) (*ServerTaskStatus, error) {
+	action := &DedicatedServerAction{
+		Action: ServerActionRestart,
+	}
+	return s.ServerAction(ctx, serverID, action)
+}
+
+// ReinstallServer переустанавливает ОС на сервере
+func (s *ServersService) ReinstallServer(ctx context.Context, serverID int, reinstallOpts *ServerReinstallOpts) (*ServerTaskStatus, error) {
+	params := map[string]interface{}{
+		"os_id": reinstallOpts.OSID,
+	}
+
+	if len(reinstallOpts.SSHKeys) > 0 {
+		params["ssh_keys"] = reinstallOpts.SSHKeys
+	}
+
+	if reinstallOpts.CustomTemplateUUID != "" {
+		params["custom_template_uuid"] = reinstallOpts.CustomTemplateUUID
+	}
+
+	if reinstallOpts.UserData != "" {
+		params["user_data"] = reinstallOpts.UserData
+	}
+
+	if len(reinstallOpts.PartitionsConfig) > 0 {
+		params["partitions_config"] = reinstallOpts.PartitionsConfig
+	}
+
+	action := &DedicatedServerAction{
+		Action: ServerActionReinstall,
+		Params: params,
+	}
+
+	return s.ServerAction(ctx, serverID, action)
+}
+
+// PowerCycleServer выполняет жесткий перезапуск сервера
+func (s *ServersService) PowerCycleServer(ctx context.Context, serverID int) (*ServerTaskStatus, error) {
+	action := &DedicatedServerAction{
+		Action: ServerActionPowerCycle,
+	}
+	return s.ServerAction(ctx, serverID, action)
+}
+
+// GetTask возвращает статус выполнения задачи
+func (s *ServersService) GetTask(ctx context.Context, taskID int) (*ServerTaskStatus, error) {
+	path := fmt.Sprintf("task/%d", taskID)
+
+	resp, err := s.client.DoRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Data *ServerTaskStatus `json:"data"`
+	}
+
+	if err := s.client.ParseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Data, nil
+}
+
+// WaitForTask ожидает завершения задачи с таймаутом
+func (s *ServersService) WaitForTask(ctx context.Context, taskID int) (*ServerTaskStatus, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+			task, err := s.GetTask(ctx, taskID)
+			if err != nil {
+				if !IsRetryable(err) {
+					return nil, err
+				}
+				// Временная ошибка API, повторяем опрос
+				log.Printf("[DEBUG] Retrying task %d status request: %s", taskID, err)
+				task = &ServerTaskStatus{ID: taskID, Status: TaskStatusRunning}
+			}
+
+			switch task.Status {
+			case TaskStatusCompleted:
+				return task, nil
+			case TaskStatusFailed:
+				return task, fmt.Errorf("task failed: %s", task.Error)
+			case TaskStatusCancelled:
+				return task, fmt.Errorf("task was cancelled")
+			default:
+				// Задача еще выполняется, ждем
+				select {
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				case <-time.After(5 * time.Second):
+					continue
+				}
+			}
+		}
+	}
+}
+
+// ListConfigurations возвращает список доступных конфигураций серверов
+func (s *ServersService) ListConfigurations(ctx context.Context) ([]*ServerConfiguration, error) {
+	path := "configuration"
+
+	resp, err := s.client.DoRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Data []*ServerConfiguration `json:"data"`
+	}
+
+	if err := s.client.ParseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Data, nil
+}
+
+// GetConfiguration возвращает информацию о конкретной конфигурации
+func (s *ServersService) GetConfiguration(ctx context.Context, configID int) (*ServerConfiguration, error) {
+	path := fmt.Sprintf("configuration/%d", configID)
+
+	resp, err := s.client.DoRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Data *ServerConfiguration `json:"data"`
+	}
+
+	if err := s.client.ParseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Data, nil
+}
+
+// ListLocations возвращает список доступных локаций
+func (s *ServersService) ListLocations(ctx context.Context) ([]*ServerLocation, error) {
+	path := "location"
+
+	resp, err := s.client.DoRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		TaskID    string            `json:"task_id"`
+		Status    string            `json:"status"`
+		Progress  int               `json:"progress"`
+		Page      int               `json:"page"`
+		Limit     int               `json:"limit"`
+		ItemCount int               `json:"item_count"`
+		Result    []*ServerLocation `json:"result"`
+	}
+
+	if err := s.client.ParseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Result, nil
+}
+
+// ListOperatingSystems возвращает список доступных операционных систем
+func (s *ServersService) ListOperatingSystems(ctx context.Context) ([]*ServerOS, error) {
+	path := "os"
+
+	resp, err := s.client.DoRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result []*ServerOS `json:"result"`
+	}
+
+	if err := s.client.ParseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Result, nil
+}
+
+// GetServices возвращает список доступных сервисов для получения service_uuid
+func (s *ServersService) GetServices(ctx context.Context) ([]*ServerService, error) {
+	path := "service"
+
+	resp, err := s.client.DoRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result []*ServerService `json:"result"`
+	}
+
+	if err := s.client.ParseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Result, nil
+}
+
+// ListServerAvailability возвращает наличие серверов по услугам и локациям
+func (s *ServersService) ListServerAvailability(ctx context.Context, opts *ServerAvailabilityListOpts) ([]*ServerAvailability, error) {
+	path := "service/availability"
+
+	if opts != nil {
+		query := url.Values{}
+		if opts.ServiceUUID != "" {
+			query.Set("service_uuid", opts.ServiceUUID)
+		}
+		if opts.LocationUUID != "" {
+			query.Set("location_uuid", opts.LocationUUID)
+		}
+		if len(query) > 0 {
+			path += "?" + query.Encode()
+		}
+	}
+
+	resp, err := s.client.DoRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result []*ServerAvailability `json:"result"`
+	}
+
+	if err := s.client.ParseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Result, nil
+}
+
+// ListOperatingSystemsNew возвращает список доступных операционных систем через новый эндпоинт
+func (s *ServersService) ListOperatingSystemsNew(ctx context.Context, opts *OperatingSystemsListOpts) ([]*ServerOS, error) {
+	path := "boot/template/os/new?" + url.Values{
+		"location_uuid": {opts.LocationUUID},
+		"service_uuid":  {opts.ServiceUUID},
+	}.Encode()
+
+	resp, err := s.client.DoRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Data []*ServerOS `json:"data"`
+	}
+
+	if err := s.client.ParseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Data, nil
+}
+
+// ListOSTemplates возвращает пользовательские шаблоны установки ОС
+func (s *ServersService) ListOSTemplates(ctx context.Context) ([]*ServerOSTemplate, error) {
+	path := "boot/template/os/custom"
+
+	resp, err := s.client.DoRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result []*ServerOSTemplate `json:"result"`
+	}
+
+	if err := s.client.ParseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Result, nil
+}
+
+// GetOSTemplate возвращает пользовательский шаблон установки ОС
+func (s *ServersService) GetOSTemplate(ctx context.Context, templateUUID string) (*ServerOSTemplate, error) {
+	path := fmt.Sprintf("boot/template/os/custom/%s", templateUUID)
+
+	resp, err := s.client.DoRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result *ServerOSTemplate `json:"result"`
+	}
+
+	if err := s.client.ParseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Result, nil
+}
+
+// CreateOSTemplate создает пользовательский шаблон установки ОС
+func (s *ServersService) CreateOSTemplate(ctx context.Context, createOpts *ServerOSTemplateCreate) (*ServerOSTemplate, error) {
+	path := "boot/template/os/custom"
+
+	resp, err := s.client.DoRequest(ctx, http.MethodPost, path, createOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result *ServerOSTemplate `json:"result"`
+	}
+
+	if err := s.client.ParseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Result, nil
+}
+
+// UpdateOSTemplate обновляет имя, описание или содержимое шаблона
+func (s *ServersService) UpdateOSTemplate(ctx context.Context, templateUUID string, updateOpts *ServerOSTemplateUpdate) (*ServerOSTemplate, error) {
+	path := fmt.Sprintf("boot/template/os/custom/%s", templateUUID)
+
+	resp, err := s.client.DoRequest(ctx, http.MethodPatch, path, updateOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result *ServerOSTemplate `json:"result"`
+	}
+
+	if err := s.client.ParseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Result, nil
+}
+
+// DeleteOSTemplate удаляет пользовательский шаблон установки ОС
+func (s *ServersService) DeleteOSTemplate(ctx context.Context, templateUUID string) error {
+	path := fmt.Sprintf("boot/template/os/custom/%s", templateUUID)
+
+	resp, err := s.client.DoRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return err
+	}
+
+	return s.client.ParseResponse(resp, nil)
+}
+
+// CreateServerResource создает новый выделенный сервер через эндпоинт биллинга
+func (s *ServersService) CreateServerResource(ctx context.Context, createOpts *DedicatedServerCreateBilling) (*DedicatedServerCreateResponse, error) {
+	path := "resource/serverchip/billing"
+
+	log.Printf("[DEBUG] CreateServerResource: UserHostname='%s', UserDesc='%s', Quantity=%d", createOpts.UserHostname, createOpts.UserDesc, createOpts.Quantity)
+
+	resp, err := s.client.DoRequest(ctx, http.MethodPost, path, createOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	var result DedicatedServerCreateResponse
+
+	if err := s.client.ParseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ListSSHKeys возвращает список SSH ключей аккаунта
+func (s *ServersService) ListSSHKeys(ctx context.Context) ([]*ServerSSHKey, error) {
+	path := "ssh/key"
+
+	resp, err := s.client.DoRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result []*ServerSSHKey `json:"result"`
+	}
+
+	if err := s.client.ParseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Result, nil
+}
+
+// GetSSHKey возвращает информацию о SSH ключе
+func (s *ServersService) GetSSHKey(ctx context.Context, keyUUID string) (*ServerSSHKey, error) {
+	path := fmt.Sprintf("ssh/key/%s", keyUUID)
+
+	resp, err := s.client.DoRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result *ServerSSHKey `json:"result"`
+	}
+
+	if err := s.client.ParseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Result, nil
+}
+
+// CreateSSHKey регистрирует новый SSH ключ
+func (s *ServersService) CreateSSHKey(ctx context.Context, createOpts *ServerSSHKeyCreate) (*ServerSSHKey, error) {
+	path := "ssh/key"
+
+	resp, err := s.client.DoRequest(ctx, http.MethodPost, path, createOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result *ServerSSHKey `json:"result"`
+	}
+
+	if err := s.client.ParseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Result, nil
+}
+
+// UpdateSSHKey обновляет имя или публичную часть SSH ключа
+func (s *ServersService) UpdateSSHKey(ctx context.Context, keyUUID string, updateOpts *ServerSSHKeyUpdate) (*ServerSSHKey, error) {
+	path := fmt.Sprintf("ssh/key/%s", keyUUID)
+
+	resp, err := s.client.DoRequest(ctx, http.MethodPatch, path, updateOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result *ServerSSHKey `json:"result"`
+	}
+
+	if err := s.client.ParseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Result, nil
+}
+
+// DeleteSSHKey удаляет SSH ключ
+func (s *ServersService) DeleteSSHKey(ctx context.Context, keyUUID string) error {
+	path := fmt.Sprintf("ssh/key/%s", keyUUID)
+
+	resp, err := s.client.DoRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return err
+	}
+
+	return s.client.ParseResponse(resp, nil)
+}
+
+// GetServerHardware возвращает аппаратную инвентаризацию сервера
+func (s *ServersService) GetServerHardware(ctx context.Context, serverID string) (*ServerHardware, error) {
+	path := fmt.Sprintf("server/%s/hardware", serverID)
+
+	resp, err := s.client.DoRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result *ServerHardware `json:"result"`
+	}
+
+	if err := s.client.ParseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Result, nil
+}
+
+// GetServerBilling возвращает параметры аренды сервера
+func (s *ServersService) GetServerBilling(ctx context.Context, serverUUID string) (*ServerBilling, error) {
+	path := fmt.Sprintf("resource/serverchip/billing/%s", serverUUID)
+
+	resp, err := s.client.DoRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result *ServerBilling `json:"result"`
+	}
+
+	if err := s.client.ParseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Result, nil
+}
+
+// UpdateServerBilling изменяет период оплаты и автопродление аренды
+func (s *ServersService) UpdateServerBilling(ctx context.Context, serverUUID string, updateOpts *ServerBillingUpdate) (*ServerBilling, error) {
+	path := fmt.Sprintf("resource/serverchip/billing/%s", serverUUID)
+
+	resp, err := s.client.DoRequest(ctx, http.MethodPatch, path, updateOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result *ServerBilling `json:"result"`
+	}
+
+	if err := s.client.ParseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Result, nil
+}
+
+// ProlongServer продлевает аренду сервера на указанное число периодов
+func (s *ServersService) ProlongServer(ctx context.Context, serverUUID string, prolongOpts *ServerBillingProlong) (*ServerBilling, error) {
+	path := fmt.Sprintf("resource/serverchip/billing/%s/prolong", serverUUID)
+
+	resp, err := s.client.DoRequest(ctx, http.MethodPost, path, prolongOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result *ServerBilling `json:"result"`
+	}
+
+	if err := s.client.ParseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Result, nil
+}
+
+// CancelServerResource отказывается от аренды сервера сразу или в конце
+// оплаченного периода
+func (s *ServersService) CancelServerResource(ctx context.Context, serverUUID string, cancelOpts *ServerCancelOpts) error {
+	path := fmt.Sprintf("resource/serverchip/billing/%s", serverUUID)
+	if cancelOpts != nil && cancelOpts.Mode != "" {
+		path += "?cancel_mode=" + url.QueryEscape(cancelOpts.Mode)
+	}
+
+	resp, err := s.client.DoRequest(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return err
+	}
+
+	return s.client.ParseResponse(resp, nil)
+}
+
+// GetServerLabels возвращает метки сервера
+func (s *ServersService) GetServerLabels(ctx context.Context, serverUUID string) (*ServerLabels, error) {
+	path := fmt.Sprintf("resource/serverchip/%s/labels", serverUUID)
+
+	resp, err := s.client.DoRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result *ServerLabels `json:"result"`
+	}
+
+	if err := s.client.ParseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Result, nil
+}
+
+// SetServerLabels заменяет метки сервера целиком
+func (s *ServersService) SetServerLabels(ctx context.Context, serverUUID string, labelsOpts *ServerLabels) error {
+	path := fmt.Sprintf("resource/serverchip/%s/labels", serverUUID)
+
+	resp, err := s.client.DoRequest(ctx, http.MethodPut, path, labelsOpts)
+	if err != nil {
+		return err
+	}
+
+	return s.client.ParseResponse(resp, nil)
+}
+
+// GetServerLock возвращает состояние блокировки сервера от удаления
+func (s *ServersService) GetServerLock(ctx context.Context, serverUUID string) (*ServerLock, error) {
+	path := fmt.Sprintf("resource/serverchip/%s/lock", serverUUID)
+
+	resp, err := s.client.DoRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result *ServerLock `json:"result"`
+	}
+
+	if err := s.client.ParseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Result, nil
+}
+
+// SetServerLock включает или снимает блокировку сервера от удаления
+func (s *ServersService) SetServerLock(ctx context.Context, serverUUID string, lockOpts *ServerLock) error {
+	path := fmt.Sprintf("resource/serverchip/%s/lock", serverUUID)
+
+	resp, err := s.client.DoRequest(ctx, http.MethodPut, path, lockOpts)
+	if err != nil {
+		return err
+	}
+
+	return s.client.ParseResponse(resp, nil)
+}
+
+// GetServerTraffic возвращает потребление трафика сервером за период
+func (s *ServersService) GetServerTraffic(ctx context.Context, serverID string, opts *ServerTrafficOpts) (*ServerTraffic, error) {
+	path := fmt.Sprintf("server/%s/traffic", serverID)
+	if opts != nil && opts.Period != "" {
+		path += "?period=" + url.QueryEscape(opts.Period)
+	}
+
+	resp, err := s.client.DoRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Result *ServerTraffic `json:"result"`
+	}
+
+	if err := s.client.ParseResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Result, nil
+}
@@ -0,0 +1,69 @@
+package servers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestService(t *testing.T, handler http.HandlerFunc) *ServersService {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := NewServersClient(&ServersClientOptions{
+		Token:   "token",
+		BaseURL: server.URL + "/servers/v2/",
+	})
+	require.NoError(t, err)
+
+	return NewServersService(client)
+}
+
+func TestCancelServerResourceQuery(t *testing.T) {
+	var gotPath, gotQuery string
+	service := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotQuery = r.URL.Path, r.URL.RawQuery
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	err := service.CancelServerResource(context.Background(), "uuid-1", &ServerCancelOpts{Mode: ServerCancelModeImmediate})
+	require.NoError(t, err)
+	assert.Equal(t, "/servers/v2/resource/serverchip/billing/uuid-1", gotPath)
+	assert.Equal(t, "cancel_mode=immediate", gotQuery)
+}
+
+func TestListOperatingSystemsNewQuery(t *testing.T) {
+	var gotQuery string
+	service := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.RawQuery
+		_, _ = w.Write([]byte(`{"data":[{"id":5,"name":"Ubuntu"}]}`))
+	})
+
+	operatingSystems, err := service.ListOperatingSystemsNew(context.Background(), &OperatingSystemsListOpts{
+		LocationUUID: "location",
+		ServiceUUID:  "service",
+	})
+	require.NoError(t, err)
+	require.Len(t, operatingSystems, 1)
+	assert.Equal(t, "location_uuid=location&service_uuid=service", gotQuery)
+}
+
+func TestParseResponseAPIError(t *testing.T) {
+	service := newTestService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code":404,"message":"server not found"}`))
+	})
+
+	_, err := service.GetServer(context.Background(), 1)
+	require.Error(t, err)
+
+	apiErr, ok := err.(*ServersAPIError)
+	require.True(t, ok)
+	assert.Equal(t, 404, apiErr.Code)
+}
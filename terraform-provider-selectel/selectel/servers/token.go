@@ -0,0 +1,108 @@
+package servers
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// DefaultTokenRefreshMargin — за сколько до истечения токен обновляется
+// заранее, чтобы он не истек посреди запроса
+const DefaultTokenRefreshMargin = 5 * time.Minute
+
+// Token представляет токен API. Нулевой ExpiresAt означает, что срок
+// действия неизвестен и токен обновляется только после отказа API
+type Token struct {
+	Value     string
+	ExpiresAt time.Time
+}
+
+// TokenSource выдает токены для запросов к API выделенных серверов
+type TokenSource interface {
+	// Token возвращает действующий токен, при необходимости получая новый
+	Token(ctx context.Context) (string, error)
+
+	// Invalidate сообщает, что API отклонило token. Следующий вызов Token
+	// получит новый токен
+	Invalidate(token string)
+}
+
+// TokenFetchFunc получает новый токен. Каждый вызов должен выдавать новый
+// токен, а не кешированный: предыдущий мог истечь или быть отклонен API
+type TokenFetchFunc func(ctx context.Context) (*Token, error)
+
+// RefreshingTokenSource кеширует токен и получает новый незадолго до
+// истечения срока или после отказа API
+type RefreshingTokenSource struct {
+	mu     sync.Mutex
+	fetch  TokenFetchFunc
+	margin time.Duration
+	now    func() time.Time
+	token  *Token
+}
+
+// NewRefreshingTokenSource создает источник токенов, который получает их
+// через fetch и обновляет за margin до истечения
+func NewRefreshingTokenSource(fetch TokenFetchFunc, margin time.Duration) *RefreshingTokenSource {
+	return &RefreshingTokenSource{
+		fetch:  fetch,
+		margin: margin,
+		now:    time.Now,
+	}
+}
+
+// Token возвращает кешированный токен или получает новый
+func (s *RefreshingTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != nil && !s.expiringLocked() {
+		return s.token.Value, nil
+	}
+
+	token, err := s.fetch(ctx)
+	if err != nil {
+		return "", err
+	}
+	if token == nil || token.Value == "" {
+		return "", errors.New("token source returned an empty token")
+	}
+
+	s.token = token
+
+	return token.Value, nil
+}
+
+// Invalidate сбрасывает кешированный токен, если API отклонило именно его.
+// Токен, уже замененный параллельным запросом, не сбрасывается
+func (s *RefreshingTokenSource) Invalidate(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != nil && s.token.Value == token {
+		s.token = nil
+	}
+}
+
+func (s *RefreshingTokenSource) expiringLocked() bool {
+	if s.token.ExpiresAt.IsZero() {
+		return false
+	}
+
+	return !s.now().Add(s.margin).Before(s.token.ExpiresAt)
+}
+
+// staticTokenSource всегда выдает один и тот же токен
+type staticTokenSource string
+
+// StaticTokenSource возвращает источник, который всегда выдает token
+func StaticTokenSource(token string) TokenSource {
+	return staticTokenSource(token)
+}
+
+func (s staticTokenSource) Token(_ context.Context) (string, error) {
+	return string(s), nil
+}
+
+func (s staticTokenSource) Invalidate(_ string) {}
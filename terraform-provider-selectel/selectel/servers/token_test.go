@@ -0,0 +1,117 @@
+package servers
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefreshingTokenSourceExpiry(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var fetches int
+	source := NewRefreshingTokenSource(func(_ context.Context) (*Token, error) {
+		fetches++
+		return &Token{Value: fmt.Sprintf("token-%d", fetches), ExpiresAt: now.Add(time.Hour)}, nil
+	}, 5*time.Minute)
+	source.now = func() time.Time { return now }
+
+	ctx := context.Background()
+	token, err := source.Token(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	now = now.Add(50 * time.Minute)
+	token, err = source.Token(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	// За margin до истечения токен обновляется заранее
+	now = now.Add(6 * time.Minute)
+	token, err = source.Token(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "token-2", token)
+	assert.Equal(t, 2, fetches)
+}
+
+func TestRefreshingTokenSourceInvalidate(t *testing.T) {
+	var fetches int
+	source := NewRefreshingTokenSource(func(_ context.Context) (*Token, error) {
+		fetches++
+		return &Token{Value: fmt.Sprintf("token-%d", fetches)}, nil
+	}, DefaultTokenRefreshMargin)
+
+	ctx := context.Background()
+	token, err := source.Token(ctx)
+	require.NoError(t, err)
+
+	// Токен, который уже заменен, не сбрасывает текущий
+	source.Invalidate("stale")
+	current, err := source.Token(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, token, current)
+
+	source.Invalidate(token)
+	current, err = source.Token(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "token-2", current)
+	assert.Equal(t, 2, fetches)
+}
+
+func TestDoRequestRefreshesTokenOnUnauthorized(t *testing.T) {
+	var tokens, bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		tokens = append(tokens, r.Header.Get("X-Auth-Token"))
+		bodies = append(bodies, string(body))
+		if r.Header.Get("X-Auth-Token") != "fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	issued := []string{"expired", "fresh"}
+	source := NewRefreshingTokenSource(func(_ context.Context) (*Token, error) {
+		token := issued[0]
+		issued = issued[1:]
+		return &Token{Value: token}, nil
+	}, DefaultTokenRefreshMargin)
+
+	client, err := NewServersClient(&ServersClientOptions{TokenSource: source, BaseURL: server.URL})
+	require.NoError(t, err)
+
+	resp, err := client.DoRequest(context.Background(), http.MethodPost, "ssh-key", map[string]string{"name": "key"})
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, []string{"expired", "fresh"}, tokens)
+	assert.Equal(t, bodies[0], bodies[1])
+}
+
+func TestDoRequestStaticTokenUnauthorized(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client, err := NewServersClient(&ServersClientOptions{Token: "revoked", BaseURL: server.URL})
+	require.NoError(t, err)
+
+	resp, err := client.DoRequest(context.Background(), http.MethodGet, "location", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, 1, requests)
+}
@@ -0,0 +1,477 @@
+package selectel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/fakeservers"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/servers"
+)
+
+// testServersFakeProviderBlock satisfies the required provider attributes.
+// Keystone is never called because the servers client is injected.
+const testServersFakeProviderBlock = `
+provider "selectel" {
+  auth_url    = "https://cloud.api.selcloud.ru/identity/v3/"
+  auth_region = "ru-9"
+  domain_name = "000000"
+  username    = "fake"
+  password    = "fake"
+}
+`
+
+// testServersFakePreCheck skips resource.UnitTest cases when there is no
+// Terraform CLI to run them with.
+func testServersFakePreCheck(t *testing.T) {
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") != "" || os.Getenv("TF_ACC_TERRAFORM_VERSION") != "" {
+		return
+	}
+	if _, err := exec.LookPath("terraform"); err != nil {
+		t.Skip("terraform CLI is not available, set TF_ACC_TERRAFORM_PATH to run unit tests against the fake servers API")
+	}
+}
+
+// testServersFakeProviderFactories returns providers whose servers client
+// talks to the fake API.
+func testServersFakeProviderFactories(api *fakeservers.API) map[string]func() (*schema.Provider, error) {
+	return map[string]func() (*schema.Provider, error){
+		"selectel": func() (*schema.Provider, error) {
+			provider := Provider()
+			provider.ConfigureContextFunc = func(_ context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+				config, diagErr := getConfig(d)
+				if diagErr != nil {
+					return nil, diagErr
+				}
+
+				client, err := testServersFakeClient(api)
+				if err != nil {
+					return nil, diag.FromErr(err)
+				}
+				testServersFakeInjectClient(config, client)
+
+				return config, nil
+			}
+
+			return provider, nil
+		},
+	}
+}
+
+func testServersFakeClient(api *fakeservers.API) (*servers.ServersClient, error) {
+	return servers.NewServersClient(&servers.ServersClientOptions{
+		Token:   api.Token,
+		BaseURL: api.URL,
+	})
+}
+
+// testServersFakeConfig returns provider meta for calling CRUD functions
+// directly, without the Terraform CLI.
+func testServersFakeConfig(t *testing.T, api *fakeservers.API) *Config {
+	client, err := testServersFakeClient(api)
+	require.NoError(t, err)
+
+	config := &Config{}
+	testServersFakeInjectClient(config, client)
+
+	return config
+}
+
+// testServersFakeInjectClient makes config return client instead of creating
+// a servers client from its credentials.
+func testServersFakeInjectClient(config *Config, client *servers.ServersClient) {
+	config.serversClients = map[string]*servers.ServersClient{config.serversClientCacheKey(): client}
+}
+
+// testServersFakePlan plans raw as the new configuration of the resource in
+// d, including CustomizeDiff, and returns the diff together with the data
+// an apply would pass to Update. The data also returns raw from
+// GetRawConfig, as it does during a real apply.
+func testServersFakePlan(ctx context.Context, t *testing.T, r *schema.Resource, d *schema.ResourceData, meta interface{}, raw map[string]interface{},
+) (*terraform.InstanceDiff, *schema.ResourceData, error) {
+	state := d.State()
+	if state != nil {
+		rawJSON, err := json.Marshal(raw)
+		require.NoError(t, err)
+		state.RawConfig, err = ctyjson.Unmarshal(rawJSON, schema.InternalMap(r.Schema).CoreConfigSchema().ImpliedType())
+		require.NoError(t, err)
+	}
+
+	diff, err := schema.InternalMap(r.Schema).Diff(ctx, state, terraform.NewResourceConfigRaw(raw), r.CustomizeDiff, meta, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	next, err := schema.InternalMap(r.Schema).Data(state, diff)
+	require.NoError(t, err)
+
+	return diff, next, nil
+}
+
+func TestServersServiceFake(t *testing.T) {
+	api := fakeservers.New()
+	defer api.Close()
+
+	api.AddServer(fakeservers.Server{Name: "web-1", Status: servers.ServerStatusActive})
+	api.AddServer(fakeservers.Server{Name: "db-1", Status: servers.ServerStatusStopped})
+
+	serversService, err := testServersFakeConfig(t, api).GetServersService()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	locations, err := serversService.ListLocations(ctx)
+	require.NoError(t, err)
+	require.Len(t, locations, 1)
+	assert.Equal(t, fakeservers.LocationUUID, locations[0].UUID)
+
+	services, err := serversService.GetServices(ctx)
+	require.NoError(t, err)
+	require.Len(t, services, 1)
+	assert.Equal(t, fakeservers.ServiceUUID, services[0].UUID)
+
+	stopped, err := serversService.ListServers(ctx, &servers.ServersListOptions{Status: servers.ServerStatusStopped})
+	require.NoError(t, err)
+	require.Len(t, stopped, 1)
+	assert.Equal(t, "db-1", stopped[0].Name)
+
+	_, err = serversService.GetServer(ctx, 1)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, servers.ErrNotFound))
+
+	var apiErr *servers.ServersAPIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.NotEmpty(t, apiErr.RequestID)
+}
+
+func TestProviderServersEndpointFake(t *testing.T) {
+	api := fakeservers.New()
+	defer api.Close()
+
+	provider := Provider()
+	diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+		"auth_url":         "https://cloud.api.selcloud.ru/identity/v3/",
+		"auth_region":      "ru-9",
+		"domain_name":      "000000",
+		"username":         "fake",
+		"password":         "fake",
+		"servers_token":    api.Token,
+		"servers_endpoint": api.URL,
+		"request_timeout":  5,
+	}))
+	require.False(t, diags.HasError(), diags)
+
+	config := provider.Meta().(*Config)
+	client, err := config.GetServersClient()
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Second, client.HTTPClient.Timeout)
+
+	serversService, err := config.GetServersService()
+	require.NoError(t, err)
+
+	locations, err := serversService.ListLocations(context.Background())
+	require.NoError(t, err)
+	require.Len(t, locations, 1)
+	assert.Equal(t, fakeservers.LocationUUID, locations[0].UUID)
+}
+
+func TestServersServiceFakeInjectedFailure(t *testing.T) {
+	api := fakeservers.New()
+	defer api.Close()
+
+	api.AddHook(fakeservers.Hook{
+		Method:    http.MethodPost,
+		Path:      "resource/serverchip/billing",
+		Status:    http.StatusConflict,
+		ErrorCode: servers.ErrorCodeOutOfStock,
+		Message:   "out of stock",
+		Times:     1,
+	})
+
+	serversService, err := testServersFakeConfig(t, api).GetServersService()
+	require.NoError(t, err)
+
+	createOpts := &servers.DedicatedServerCreateBilling{
+		Name:         "node-1",
+		LocationUUID: fakeservers.LocationUUID,
+		ServiceUUID:  fakeservers.ServiceUUID,
+	}
+
+	_, err = serversService.CreateServerResource(context.Background(), createOpts)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, servers.ErrOutOfStock))
+	assert.False(t, errors.Is(err, servers.ErrConflict))
+
+	response, err := serversService.CreateServerResource(context.Background(), createOpts)
+	require.NoError(t, err)
+	require.Len(t, response.Result, 1)
+}
+
+func TestServersServiceFakeWaitForTask(t *testing.T) {
+	api := fakeservers.New()
+	defer api.Close()
+
+	serversService, err := testServersFakeConfig(t, api).GetServersService()
+	require.NoError(t, err)
+
+	failed := api.AddTask("disk not found")
+	task, err := serversService.WaitForTask(context.Background(), failed.ID)
+	require.Error(t, err)
+	assert.Equal(t, servers.TaskStatusFailed, task.Status)
+	assert.Contains(t, err.Error(), "disk not found")
+}
+
+func TestServersTokenRefreshFake(t *testing.T) {
+	api := fakeservers.New()
+	defer api.Close()
+
+	ctx := context.Background()
+
+	// Keystone выдает токен, срок которого истекает раньше запаса на
+	// обновление, поэтому каждый запрос получает новый токен до отправки
+	var issued int
+	config := &Config{
+		ServersEndpoint: api.URL,
+		serversKeystoneToken: func(_ context.Context) (*servers.Token, error) {
+			issued++
+			token := fmt.Sprintf("keystone-token-%d", issued)
+			api.SetToken(token)
+
+			return &servers.Token{Value: token, ExpiresAt: time.Now().Add(time.Minute)}, nil
+		},
+	}
+
+	serversService, err := config.GetServersService()
+	require.NoError(t, err)
+
+	_, err = serversService.ListLocations(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, issued)
+
+	// Истекающий токен Keystone обновляется заранее, без лишнего 401
+	_, err = serversService.ListLocations(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, issued)
+	assert.Len(t, api.Requests(), 2)
+}
+
+func TestServersKeystoneTokenExpiry(t *testing.T) {
+	expiresAt := time.Date(2026, 10, 20, 9, 30, 0, 0, time.UTC)
+	var scopes []interface{}
+	keystone := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v3/auth/tokens" {
+			http.NotFound(w, r)
+			return
+		}
+
+		var body struct {
+			Auth struct {
+				Scope interface{} `json:"scope"`
+			} `json:"auth"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		scopes = append(scopes, body.Auth.Scope)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Subject-Token", "keystone-token")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"token": {"expires_at": %q, "catalog": []}}`, expiresAt.Format("2006-01-02T15:04:05.000000Z"))
+	}))
+	defer keystone.Close()
+
+	config := &Config{
+		AuthURL:          keystone.URL + "/v3/",
+		DomainName:       "000000",
+		Username:         "user",
+		Password:         "secret",
+		ServersUsername:  "servers",
+		ServersPassword:  "servers-secret",
+		ServersProjectID: "project",
+	}
+
+	token, err := config.newServersKeystoneToken(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "keystone-token", token.Value)
+	assert.True(t, expiresAt.Equal(token.ExpiresAt), token.ExpiresAt)
+
+	// Без сервисного пользователя токен выпускается на домен аккаунта
+	config.ServersUsername = ""
+	_, err = config.newServersKeystoneToken(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"project": map[string]interface{}{"id": "project"}},
+		map[string]interface{}{"domain": map[string]interface{}{"name": "000000"}},
+	}, scopes)
+}
+
+func TestServersStaticTokenRejectedFake(t *testing.T) {
+	api := fakeservers.New()
+	defer api.Close()
+
+	// servers_token не подменяется токеном Keystone пользователя провайдера
+	config := &Config{
+		ServersToken:    api.Token,
+		ServersEndpoint: api.URL,
+		serversKeystoneToken: func(_ context.Context) (*servers.Token, error) {
+			t.Fatal("Keystone must not be used with servers_token")
+			return nil, nil
+		},
+	}
+
+	serversService, err := config.GetServersService()
+	require.NoError(t, err)
+
+	_, err = serversService.ListLocations(context.Background())
+	require.NoError(t, err)
+
+	api.SetToken("rotated")
+	_, err = serversService.ListLocations(context.Background())
+	assert.ErrorIs(t, err, servers.ErrUnauthorized)
+	assert.ErrorContains(t, err, "rejected the token of servers_token")
+	assert.Len(t, api.Requests(), 2)
+}
+
+func TestServersTokenRejectedFake(t *testing.T) {
+	api := fakeservers.New()
+	defer api.Close()
+
+	config := &Config{
+		ServersEndpoint: api.URL,
+		serversKeystoneToken: func(_ context.Context) (*servers.Token, error) {
+			return &servers.Token{Value: "expired", ExpiresAt: time.Now().Add(time.Hour)}, nil
+		},
+	}
+
+	serversService, err := config.GetServersService()
+	require.NoError(t, err)
+
+	_, err = serversService.ListLocations(context.Background())
+	require.Error(t, err)
+
+	var apiErr *servers.ServersAPIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+	// Keystone снова выдал тот же токен, повторять запрос незачем
+	assert.Len(t, api.Requests(), 1)
+}
+
+// testServersFakeProviderAlias returns a provider block talking to api.
+func testServersFakeProviderAlias(alias string, api *fakeservers.API) string {
+	return fmt.Sprintf(`
+provider "selectel" {
+  alias            = %q
+  auth_url         = "https://cloud.api.selcloud.ru/identity/v3/"
+  auth_region      = "ru-9"
+  domain_name      = "000000"
+  username         = %q
+  password         = "fake"
+  servers_token    = %q
+  servers_endpoint = %q
+}
+`, alias, alias, api.Token, api.URL)
+}
+
+func TestUnitProviderAliasesFake(t *testing.T) {
+	first := fakeservers.New()
+	defer first.Close()
+	second := fakeservers.New()
+	defer second.Close()
+
+	second.SetToken("second-account-token")
+	second.AddLocation(fakeservers.Location{
+		UUID:       "0b1f3a57-9b67-5bd6-a7a1-3f0bd5cc2f73",
+		Name:       "MSK-2",
+		LocationID: 2,
+		Region:     "ru-2",
+		Visibility: "public",
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck: func() { testServersFakePreCheck(t) },
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"selectel": func() (*schema.Provider, error) {
+				return Provider(), nil
+			},
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testServersFakeProviderAlias("first", first) + testServersFakeProviderAlias("second", second) + `
+data "selectel_dedicated_server_locations_v1" "first" {
+  provider = selectel.first
+}
+
+data "selectel_dedicated_server_locations_v1" "second" {
+  provider = selectel.second
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.selectel_dedicated_server_locations_v1.first", "locations.#", "1"),
+					resource.TestCheckResourceAttr("data.selectel_dedicated_server_locations_v1.second", "locations.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestProviderAliasesFake(t *testing.T) {
+	first := fakeservers.New()
+	defer first.Close()
+	second := fakeservers.New()
+	defer second.Close()
+
+	second.SetToken("second-account-token")
+
+	configure := func(api *fakeservers.API, username string) *Config {
+		provider := Provider()
+		diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{
+			"auth_url":         "https://cloud.api.selcloud.ru/identity/v3/",
+			"auth_region":      "ru-9",
+			"domain_name":      "000000",
+			"username":         username,
+			"password":         "fake",
+			"servers_token":    api.Token,
+			"servers_endpoint": api.URL,
+		}))
+		require.False(t, diags.HasError(), diags)
+
+		return provider.Meta().(*Config)
+	}
+
+	firstConfig := configure(first, "first")
+	secondConfig := configure(second, "second")
+	require.NotSame(t, firstConfig, secondConfig)
+
+	for _, config := range []*Config{firstConfig, secondConfig, firstConfig} {
+		serversService, err := config.GetServersService()
+		require.NoError(t, err)
+
+		_, err = serversService.ListLocations(context.Background())
+		require.NoError(t, err)
+	}
+
+	assert.Len(t, first.Requests(), 2)
+	assert.Len(t, second.Requests(), 1)
+
+	firstClient, err := firstConfig.GetServersClient()
+	require.NoError(t, err)
+	secondClient, err := secondConfig.GetServersClient()
+	require.NoError(t, err)
+	assert.NotSame(t, firstClient, secondClient)
+	assert.NotEqual(t, firstConfig.credentialsCacheKey("project"), secondConfig.credentialsCacheKey("project"))
+	assert.NotEqual(t, firstConfig.credentialsCacheKey("project"), firstConfig.credentialsCacheKey("other-project"))
+}
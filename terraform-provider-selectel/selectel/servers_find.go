@@ -0,0 +1,38 @@
+package selectel
+
+import (
+	"fmt"
+)
+
+// findServerObject ищет объект по UUID, а затем по имени. Имена не обязаны
+// быть уникальными, поэтому неоднозначное совпадение считается ошибкой.
+// Если name равна nil, объект ищется только по UUID
+func findServerObject[T any](items []T, object, ref string, uuid, name func(T) string) (T, error) {
+	var found T
+	for _, item := range items {
+		if uuid(item) == ref {
+			return item, nil
+		}
+	}
+
+	if name == nil {
+		return found, fmt.Errorf("%s %q not found", object, ref)
+	}
+
+	matched := false
+	for _, item := range items {
+		if name(item) != ref {
+			continue
+		}
+		if matched {
+			return found, fmt.Errorf("several %ss are named %q, reference it by UUID", object, ref)
+		}
+		found, matched = item, true
+	}
+
+	if !matched {
+		return found, fmt.Errorf("%s %q not found", object, ref)
+	}
+
+	return found, nil
+}
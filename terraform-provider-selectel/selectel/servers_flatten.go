@@ -1,7 +1,13 @@
 package selectel
 
+import (
+	"strings"
+
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/servers"
+)
+
 // flattenServerCPU преобразует ServerCPU в формат для Terraform
-func flattenServerCPU(cpu *ServerCPU) []interface{} {
+func flattenServerCPU(cpu *servers.ServerCPU) []interface{} {
 	if cpu == nil {
 		return []interface{}{}
 	}
@@ -18,7 +24,7 @@ func flattenServerCPU(cpu *ServerCPU) []interface{} {
 }
 
 // flattenServerRAM преобразует ServerRAM в формат для Terraform
-func flattenServerRAM(ram *ServerRAM) []interface{} {
+func flattenServerRAM(ram *servers.ServerRAM) []interface{} {
 	if ram == nil {
 		return []interface{}{}
 	}
@@ -33,7 +39,7 @@ func flattenServerRAM(ram *ServerRAM) []interface{} {
 }
 
 // flattenServerStorage преобразует массив ServerStorage в формат для Terraform
-func flattenServerStorage(storage []*ServerStorage) []interface{} {
+func flattenServerStorage(storage []*servers.ServerStorage) []interface{} {
 	if storage == nil {
 		return []interface{}{}
 	}
@@ -53,7 +59,7 @@ func flattenServerStorage(storage []*ServerStorage) []interface{} {
 }
 
 // flattenServerNetwork преобразует ServerNetwork в формат для Terraform
-func flattenServerNetwork(network *ServerNetwork) []interface{} {
+func flattenServerNetwork(network *servers.ServerNetwork) []interface{} {
 	if network == nil {
 		return []interface{}{}
 	}
@@ -70,25 +76,16 @@ func flattenServerNetwork(network *ServerNetwork) []interface{} {
 }
 
 // flattenServerLocation преобразует ServerLocation в формат для Terraform
-func flattenServerLocation(location *ServerLocation) []interface{} {
+func flattenServerLocation(location *servers.ServerLocation) []interface{} {
 	if location == nil {
 		return []interface{}{}
 	}
 
-	locationMap := map[string]interface{}{
-		"id":         location.ID,
-		"name":       location.Name,
-		"code":       location.Code,
-		"country":    location.Country,
-		"city":       location.City,
-		"datacenter": location.Datacenter,
-	}
-
-	return []interface{}{locationMap}
+	return []interface{}{flattenServerLocationMap(location)}
 }
 
 // flattenServerOS преобразует ServerOS в формат для Terraform
-func flattenServerOS(os *ServerOS) []interface{} {
+func flattenServerOS(os *servers.ServerOS) []interface{} {
 	if os == nil {
 		return []interface{}{}
 	}
@@ -106,7 +103,7 @@ func flattenServerOS(os *ServerOS) []interface{} {
 }
 
 // flattenServerIPMI преобразует ServerIPMI в формат для Terraform
-func flattenServerIPMI(ipmi *ServerIPMI) []interface{} {
+func flattenServerIPMI(ipmi *servers.ServerIPMI) []interface{} {
 	if ipmi == nil {
 		return []interface{}{}
 	}
@@ -122,7 +119,7 @@ func flattenServerIPMI(ipmi *ServerIPMI) []interface{} {
 }
 
 // flattenServerBackup преобразует ServerBackup в формат для Terraform
-func flattenServerBackup(backup *ServerBackup) []interface{} {
+func flattenServerBackup(backup *servers.ServerBackup) []interface{} {
 	if backup == nil {
 		return []interface{}{}
 	}
@@ -137,7 +134,7 @@ func flattenServerBackup(backup *ServerBackup) []interface{} {
 }
 
 // flattenServerPrice преобразует ServerPrice в формат для Terraform
-func flattenServerPrice(price *ServerPrice) []interface{} {
+func flattenServerPrice(price *servers.ServerPrice) []interface{} {
 	if price == nil {
 		return []interface{}{}
 	}
@@ -152,7 +149,7 @@ func flattenServerPrice(price *ServerPrice) []interface{} {
 }
 
 // flattenServerConfigurations преобразует массив ServerConfiguration в формат для Terraform
-func flattenServerConfigurations(configs []*ServerConfiguration) []interface{} {
+func flattenServerConfigurations(configs []*servers.ServerConfiguration) []interface{} {
 	if configs == nil {
 		return []interface{}{}
 	}
@@ -176,30 +173,44 @@ func flattenServerConfigurations(configs []*ServerConfiguration) []interface{} {
 }
 
 // flattenServerLocations преобразует массив ServerLocation в формат для Terraform
-func flattenServerLocations(locations []*ServerLocation) []interface{} {
+func flattenServerLocations(locations []*servers.ServerLocation) []interface{} {
 	if locations == nil {
 		return []interface{}{}
 	}
 
 	locationList := make([]interface{}, len(locations))
 	for i, location := range locations {
-		locationMap := map[string]interface{}{
-			"id":         location.ID,
-			"uuid":       location.UUID,
-			"name":       location.Name,
-			"code":       location.Code,
-			"country":    location.Country,
-			"city":       location.City,
-			"datacenter": location.Datacenter,
-		}
-		locationList[i] = locationMap
+		locationList[i] = flattenServerLocationMap(location)
 	}
 
 	return locationList
 }
 
+// flattenServerLocationMap преобразует ServerLocation в map
+func flattenServerLocationMap(location *servers.ServerLocation) map[string]interface{} {
+	locationMap := map[string]interface{}{
+		"id":          location.LocationID,
+		"uuid":        location.UUID,
+		"name":        location.Name,
+		"description": location.Description,
+		"region":      location.Region,
+		"visibility":  location.Visibility,
+
+		// Устаревшие поля заполняются из новых, как раньше
+		"code":       serverLocationCode(location.Name),
+		"country":    "",
+		"city":       "",
+		"datacenter": location.Description,
+	}
+	if location.DCCount != nil {
+		locationMap["dc_count"] = *location.DCCount
+	}
+
+	return locationMap
+}
+
 // flattenServerOSList преобразует массив ServerOS в формат для Terraform
-func flattenServerOSList(osList []*ServerOS) []interface{} {
+func flattenServerOSList(osList []*servers.ServerOS) []interface{} {
 	if osList == nil {
 		return []interface{}{}
 	}
@@ -221,13 +232,13 @@ func flattenServerOSList(osList []*ServerOS) []interface{} {
 }
 
 // flattenDedicatedServers преобразует массив DedicatedServer в формат для Terraform
-func flattenDedicatedServers(servers []*DedicatedServer) []interface{} {
-	if servers == nil {
+func flattenDedicatedServers(dedicatedServers []*servers.DedicatedServer) []interface{} {
+	if dedicatedServers == nil {
 		return []interface{}{}
 	}
 
-	serverList := make([]interface{}, len(servers))
-	for i, server := range servers {
+	serverList := make([]interface{}, len(dedicatedServers))
+	for i, server := range dedicatedServers {
 		serverMap := map[string]interface{}{
 			"id":        server.ID,
 			"name":      server.Name,
@@ -235,25 +246,16 @@ func flattenDedicatedServers(servers []*DedicatedServer) []interface{} {
 			"status_hd": server.StatusHD,
 			"cpu":       flattenServerCPU(server.CPU),
 			"ram":       flattenServerRAM(server.RAM),
-			"storage":   flattenServerStorage(server.Storage),
-			"network":   flattenServerNetwork(server.Network),
 			"location":  flattenServerLocation(server.Location),
-			"os":        flattenServerOS(server.OS),
-			"ipmi":      flattenServerIPMI(server.IPMI),
-			"backup":    flattenServerBackup(server.Backup),
-			"price":     flattenServerPrice(server.Price),
 			"comment":   server.Comment,
 			"tags":      server.Tags,
+			"labels":    server.Labels,
 		}
 
 		if server.CreatedAt != nil {
 			serverMap["created_at"] = server.CreatedAt.Format("2006-01-02T15:04:05Z")
 		}
 
-		if server.UpdatedAt != nil {
-			serverMap["updated_at"] = server.UpdatedAt.Format("2006-01-02T15:04:05Z")
-		}
-
 		serverList[i] = serverMap
 	}
 
@@ -261,7 +263,7 @@ func flattenDedicatedServers(servers []*DedicatedServer) []interface{} {
 }
 
 // flattenServerServicesList преобразует массив ServerService в формат для Terraform
-func flattenServerServicesList(services []*ServerService) []interface{} {
+func flattenServerServicesList(services []*servers.ServerService) []interface{} {
 	if services == nil {
 		return []interface{}{}
 	}
@@ -280,3 +282,89 @@ func flattenServerServicesList(services []*ServerService) []interface{} {
 
 	return serviceList
 }
+
+// flattenServerSSHKeys преобразует массив ServerSSHKey в формат для Terraform
+func flattenServerSSHKeys(keys []*servers.ServerSSHKey) []interface{} {
+	if keys == nil {
+		return []interface{}{}
+	}
+
+	keyList := make([]interface{}, len(keys))
+	for i, key := range keys {
+		keyList[i] = map[string]interface{}{
+			"uuid":        key.UUID,
+			"name":        key.Name,
+			"fingerprint": key.Fingerprint,
+		}
+	}
+
+	return keyList
+}
+
+// flattenServerDisks преобразует массив ServerDisk в формат для Terraform
+func flattenServerDisks(disks []*servers.ServerDisk) []interface{} {
+	if disks == nil {
+		return []interface{}{}
+	}
+
+	diskList := make([]interface{}, len(disks))
+	for i, disk := range disks {
+		diskList[i] = map[string]interface{}{
+			"slot":     disk.Slot,
+			"serial":   disk.Serial,
+			"model":    disk.Model,
+			"type":     disk.Type,
+			"size_gb":  disk.SizeGB,
+			"firmware": disk.Firmware,
+		}
+	}
+
+	return diskList
+}
+
+// flattenServerNICs преобразует массив ServerNIC в формат для Terraform
+func flattenServerNICs(nics []*servers.ServerNIC) []interface{} {
+	if nics == nil {
+		return []interface{}{}
+	}
+
+	nicList := make([]interface{}, len(nics))
+	for i, nic := range nics {
+		nicList[i] = map[string]interface{}{
+			"name":        nic.Name,
+			"mac_address": nic.MACAddress,
+			"model":       nic.Model,
+			"speed_mbps":  nic.SpeedMbps,
+			"link_state":  nic.LinkState,
+		}
+	}
+
+	return nicList
+}
+
+// flattenServerPortsTraffic преобразует массив ServerPortTraffic в формат для Terraform
+func flattenServerPortsTraffic(ports []*servers.ServerPortTraffic) []interface{} {
+	if ports == nil {
+		return []interface{}{}
+	}
+
+	portList := make([]interface{}, len(ports))
+	for i, port := range ports {
+		portList[i] = map[string]interface{}{
+			"name":           port.Name,
+			"mac_address":    port.MACAddress,
+			"inbound_bytes":  int(port.InboundBytes),
+			"outbound_bytes": int(port.OutboundBytes),
+		}
+	}
+
+	return portList
+}
+
+// serverLocationCode возвращает код площадки из имени локации: SPB-4 -> SPB.
+// Нужен только для устаревшего поля code
+func serverLocationCode(name string) string {
+	code, _, _ := strings.Cut(name, "-")
+
+	return code
+}
@@ -0,0 +1,156 @@
+package selectel
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// serverLabelKeyRegexp ограничивает ключи меток так же, как панель управления
+var serverLabelKeyRegexp = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9._/-]{0,62})$`)
+
+// serverLabelRequirement описывает одно условие селектора меток
+type serverLabelRequirement struct {
+	Key      string
+	Value    string
+	Negative bool
+	// Exists означает условие вида "key" без значения
+	Exists bool
+}
+
+// expandServerLabels преобразует значение TypeMap в map[string]string
+func expandServerLabels(v interface{}) map[string]string {
+	raw, _ := v.(map[string]interface{})
+	labels := make(map[string]string, len(raw))
+	for key, value := range raw {
+		labels[key] = value.(string)
+	}
+
+	return labels
+}
+
+// mergeServerLabels объединяет метки провайдера default_labels с метками
+// ресурса, метки ресурса имеют приоритет
+func mergeServerLabels(defaults, labels map[string]string) map[string]string {
+	merged := make(map[string]string, len(defaults)+len(labels))
+	for key, value := range defaults {
+		merged[key] = value
+	}
+	for key, value := range labels {
+		merged[key] = value
+	}
+
+	return merged
+}
+
+// serverLabelsWithoutDefaults возвращает метки, которые нужно показать в
+// атрибуте labels: все метки сервера, кроме унаследованных от default_labels
+// и не переопределенных в конфигурации
+func serverLabelsWithoutDefaults(all, defaults, configured map[string]string) map[string]string {
+	labels := make(map[string]string, len(all))
+	for key, value := range all {
+		if _, ok := configured[key]; !ok {
+			if defaultValue, ok := defaults[key]; ok && defaultValue == value {
+				continue
+			}
+		}
+		labels[key] = value
+	}
+
+	return labels
+}
+
+func validateServerLabels(v interface{}, k string) ([]string, []error) {
+	var errs []error
+	for key := range v.(map[string]interface{}) {
+		if !serverLabelKeyRegexp.MatchString(key) {
+			errs = append(errs, fmt.Errorf("%q contains invalid label key %q", k, key))
+		}
+	}
+
+	return nil, errs
+}
+
+// parseServerLabelSelector разбирает селектор вида "env=prod,role!=db,team"
+func parseServerLabelSelector(selector string) ([]serverLabelRequirement, error) {
+	var requirements []serverLabelRequirement
+	for _, part := range strings.Split(selector, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		var requirement serverLabelRequirement
+		switch {
+		case strings.Contains(part, "!="):
+			kv := strings.SplitN(part, "!=", 2)
+			requirement = serverLabelRequirement{Key: kv[0], Value: kv[1], Negative: true}
+		case strings.Contains(part, "="):
+			kv := strings.SplitN(part, "=", 2)
+			requirement = serverLabelRequirement{Key: kv[0], Value: kv[1]}
+		default:
+			requirement = serverLabelRequirement{Key: part, Exists: true}
+		}
+
+		requirement.Key = strings.TrimSpace(requirement.Key)
+		requirement.Value = strings.TrimSpace(requirement.Value)
+		if !serverLabelKeyRegexp.MatchString(requirement.Key) {
+			return nil, fmt.Errorf("invalid label selector %q: bad key %q", selector, requirement.Key)
+		}
+
+		requirements = append(requirements, requirement)
+	}
+
+	return requirements, nil
+}
+
+func validateServerLabelSelector(v interface{}, k string) ([]string, []error) {
+	if _, err := parseServerLabelSelector(v.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%q: %w", k, err)}
+	}
+
+	return nil, nil
+}
+
+// matchServerLabels проверяет, что метки удовлетворяют всем условиям
+func matchServerLabels(requirements []serverLabelRequirement, labels map[string]string) bool {
+	for _, requirement := range requirements {
+		value, ok := labels[requirement.Key]
+		switch {
+		case requirement.Exists:
+			if !ok {
+				return false
+			}
+		case requirement.Negative:
+			if ok && value == requirement.Value {
+				return false
+			}
+		default:
+			if !ok || value != requirement.Value {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// dedicatedServerV1LabelsCustomizeDiff пересчитывает labels_all, чтобы
+// изменение default_labels в провайдере приводило к обновлению серверов
+func dedicatedServerV1LabelsCustomizeDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	config, ok := meta.(*Config)
+	if !ok {
+		return nil
+	}
+
+	merged := mergeServerLabels(config.DefaultLabels, expandServerLabels(d.Get("labels")))
+	if reflect.DeepEqual(merged, expandServerLabels(d.Get("labels_all"))) {
+		return nil
+	}
+
+	return d.SetNew("labels_all", merged)
+}
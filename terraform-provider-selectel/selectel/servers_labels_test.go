@@ -0,0 +1,97 @@
+package selectel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/fakeservers"
+)
+
+func TestMergeServerLabels(t *testing.T) {
+	defaults := map[string]string{"team": "infra", "env": "dev"}
+	labels := map[string]string{"env": "prod", "role": "db"}
+
+	merged := mergeServerLabels(defaults, labels)
+
+	assert.Equal(t, map[string]string{"team": "infra", "env": "prod", "role": "db"}, merged)
+	assert.Equal(t, map[string]string{"env": "dev", "team": "infra"}, defaults)
+}
+
+func TestServerLabelsWithoutDefaults(t *testing.T) {
+	all := map[string]string{"team": "infra", "env": "prod", "owner": "ops"}
+	defaults := map[string]string{"team": "infra", "env": "prod", "owner": "billing"}
+	configured := map[string]string{"env": "prod"}
+
+	labels := serverLabelsWithoutDefaults(all, defaults, configured)
+
+	assert.Equal(t, map[string]string{"env": "prod", "owner": "ops"}, labels)
+}
+
+func TestMatchServerLabels(t *testing.T) {
+	labels := map[string]string{"env": "prod", "role": "db"}
+
+	testCases := []struct {
+		selector string
+		expected bool
+	}{
+		{"", true},
+		{"env=prod", true},
+		{"env=prod, role=db", true},
+		{"env=dev", false},
+		{"role!=web", true},
+		{"role!=db", false},
+		{"env", true},
+		{"team", false},
+		{"team!=infra", true},
+	}
+
+	for _, testCase := range testCases {
+		requirements, err := parseServerLabelSelector(testCase.selector)
+		require.NoError(t, err, testCase.selector)
+		assert.Equal(t, testCase.expected, matchServerLabels(requirements, labels), testCase.selector)
+	}
+
+	_, err := parseServerLabelSelector("=prod")
+	assert.Error(t, err)
+}
+
+func TestDedicatedServerV1LabelsFake(t *testing.T) {
+	api := fakeservers.New()
+	defer api.Close()
+
+	ctx := context.Background()
+	meta := testServersFakeConfig(t, api)
+	meta.DefaultLabels = map[string]string{"team": "infra", "env": "dev"}
+
+	d := schema.TestResourceDataRaw(t, resourceDedicatedServerV1().Schema, map[string]interface{}{
+		"name":        "db-1",
+		"location_id": 1,
+		"root_size":   20,
+		"labels":      map[string]interface{}{"env": "prod", "role": "db"},
+	})
+	require.False(t, resourceDedicatedServerV1Create(ctx, d, meta).HasError())
+
+	server, ok := api.Server(d.Id())
+	require.True(t, ok)
+	assert.Equal(t, map[string]string{"team": "infra", "env": "prod", "role": "db"}, server.Labels)
+	assert.Equal(t, map[string]interface{}{"env": "prod", "role": "db"}, d.Get("labels"))
+	assert.Equal(t, map[string]interface{}{"team": "infra", "env": "prod", "role": "db"}, d.Get("labels_all"))
+
+	api.AddServer(fakeservers.Server{Name: "web-1", Labels: map[string]string{"env": "prod", "role": "web"}})
+
+	list := schema.TestResourceDataRaw(t, dataSourceDedicatedServersV1().Schema, map[string]interface{}{
+		"filter": []interface{}{map[string]interface{}{"label_selector": "env=prod,role!=web"}},
+	})
+	diags := dataSourceDedicatedServersV1Read(ctx, list, meta)
+	require.False(t, diags.HasError(), diags)
+
+	found := list.Get("servers").([]interface{})
+	require.Len(t, found, 1)
+	assert.Equal(t, "db-1", found[0].(map[string]interface{})["name"])
+
+	requests := api.Requests()
+	assert.Equal(t, "label_selector=env%3Dprod%2Crole%21%3Dweb", requests[len(requests)-1].Query)
+}
@@ -0,0 +1,124 @@
+package selectel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/servers"
+)
+
+// dedicatedServerConflictRetryDelay — пауза перед проверкой, закончилась ли
+// задача, из-за которой API отклонило вызов
+var dedicatedServerConflictRetryDelay = 10 * time.Second
+
+// dedicatedServerConflictMaxRetryDelay ограничивает паузу между повторами
+// вызова, когда числовой ID сервера неизвестен
+const dedicatedServerConflictMaxRetryDelay = 2 * time.Minute
+
+// lockDedicatedServer сериализует изменяющие вызовы к одному серверу из
+// разных ресурсов. Ресурсы ссылаются на сервер по UUID или по числовому ID,
+// поэтому блокируются все известные идентификаторы в одном порядке.
+// Возвращает функцию снятия блокировки
+func lockDedicatedServer(ids ...string) func() {
+	keys := make([]string, 0, len(ids))
+	seen := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		if id == "" || id == "0" {
+			continue
+		}
+		key := "dedicated_server/" + id
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		selMutexKV.Lock(key)
+	}
+
+	return func() {
+		for i := len(keys) - 1; i >= 0; i-- {
+			selMutexKV.Unlock(keys[i])
+		}
+	}
+}
+
+// lockDedicatedServerV1 блокирует сервер ресурса по UUID и числовому ID
+func lockDedicatedServerV1(d *schema.ResourceData) func() {
+	return lockDedicatedServer(d.Id(), strconv.Itoa(d.Get("server_id").(int)))
+}
+
+// dedicatedServerRetryOnConflict выполняет изменяющий вызов и повторяет его,
+// пока API отклоняет вызов конфликтом из-за задачи, уже выполняющейся на
+// сервере, например запущенной вне Terraform. Без числового ID конец задачи
+// не дождаться, и вызов повторяется с растущей паузой, как и после временной
+// ошибки API
+func dedicatedServerRetryOnConflict(ctx context.Context, serversService servers.ServersAPI, serverID int, timeout time.Duration, call func() error) error {
+	deadline := time.Now().Add(timeout)
+	delay := dedicatedServerConflictRetryDelay
+	for {
+		err := call()
+		if err == nil || !time.Now().Before(deadline) {
+			return err
+		}
+
+		conflict := errors.Is(err, servers.ErrConflict)
+		if !conflict && !servers.IsRetryable(err) {
+			return err
+		}
+
+		if serverID == 0 || !conflict {
+			log.Printf("[INFO] %s request failed, retrying in %s: %s", objectDedicatedServer, delay, err)
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(min(delay, time.Until(deadline))):
+			}
+			delay = min(2*delay, dedicatedServerConflictMaxRetryDelay)
+
+			continue
+		}
+
+		log.Printf("[INFO] %s %d is busy with another task, retrying after it finishes: %s", objectDedicatedServer, serverID, err)
+		if err := waitForDedicatedServerIdle(ctx, serversService, serverID, time.Until(deadline)); err != nil {
+			return err
+		}
+	}
+}
+
+// dedicatedServerV1RetryOnConflict повторяет изменяющий вызов ресурса
+// сервера, как dedicatedServerRetryOnConflict
+func dedicatedServerV1RetryOnConflict(ctx context.Context, d *schema.ResourceData, serversService servers.ServersAPI, call func() error) error {
+	return dedicatedServerRetryOnConflict(ctx, serversService, d.Get("server_id").(int), d.Timeout(schema.TimeoutUpdate), call)
+}
+
+// waitForDedicatedServerIdle ожидает, пока сервер выйдет из переходного
+// статуса, то есть закончится выполняющаяся на нем задача
+func waitForDedicatedServerIdle(ctx context.Context, serversService servers.ServersAPI, serverID int, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending: []string{
+			servers.ServerStatusInstalling, servers.ServerStatusRebooting, servers.ServerStatusMaintenance,
+		},
+		Target:     []string{servers.ServerStatusActive, servers.ServerStatusStopped},
+		Refresh:    dedicatedServerV1StateRefreshFunc(ctx, serversService, serverID),
+		Timeout:    timeout,
+		Delay:      dedicatedServerConflictRetryDelay,
+		MinTimeout: dedicatedServerConflictRetryDelay,
+	}
+
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("error waiting for the running task of server %d to finish: %w", serverID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,266 @@
+package selectel
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/fakeservers"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/servers"
+)
+
+func testDedicatedServerConflictRetryDelay(t *testing.T) {
+	delay := dedicatedServerConflictRetryDelay
+	dedicatedServerConflictRetryDelay = 10 * time.Millisecond
+	t.Cleanup(func() { dedicatedServerConflictRetryDelay = delay })
+}
+
+// testServersFakeActions returns the actions posted to the servers in order.
+func testServersFakeActions(api *fakeservers.API) []string {
+	var actions []string
+	for _, request := range api.Requests() {
+		if request.Method == http.MethodPost && strings.HasSuffix(request.Path, "/action") {
+			actions = append(actions, request.Body)
+		}
+	}
+
+	return actions
+}
+
+func TestLockDedicatedServer(t *testing.T) {
+	unlock := lockDedicatedServer("00000000-0000-4000-8000-000000001001", "1001")
+
+	locked := make(chan struct{})
+	go func() {
+		// Другой ресурс знает только числовой ID того же сервера
+		defer lockDedicatedServer("1001", "")()
+		close(locked)
+	}()
+
+	select {
+	case <-locked:
+		t.Fatal("server was locked twice")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	unlock()
+	select {
+	case <-locked:
+	case <-time.After(time.Second):
+		t.Fatal("server lock was not released")
+	}
+}
+
+func TestDedicatedServerRetryOnConflictFake(t *testing.T) {
+	testDedicatedServerConflictRetryDelay(t)
+
+	api := fakeservers.New()
+	defer api.Close()
+
+	server := api.AddServer(fakeservers.Server{Name: "web-1", Status: servers.ServerStatusActive})
+	serversService, err := testServersFakeConfig(t, api).GetServersService()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+
+	// Задача, запущенная вне Terraform, завершится немного позже
+	external, err := serversService.StopServer(ctx, server.ID)
+	require.NoError(t, err)
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		_, _ = serversService.WaitForTask(ctx, external.ID)
+	}()
+
+	var task *servers.ServerTaskStatus
+	err = dedicatedServerRetryOnConflict(ctx, serversService, server.ID, time.Minute, func() (err error) {
+		task, err = serversService.StartServer(ctx, server.ID)
+		return err
+	})
+	require.NoError(t, err)
+	require.NotNil(t, task)
+
+	actions := testServersFakeActions(api)
+	require.GreaterOrEqual(t, len(actions), 3)
+	assert.Contains(t, actions[len(actions)-1], "start")
+
+	current, ok := api.Server(strconv.Itoa(server.ID))
+	require.True(t, ok)
+	assert.Equal(t, servers.ServerStatusRebooting, current.Status)
+}
+
+func TestDedicatedServerRetryOnConflictTimeoutFake(t *testing.T) {
+	testDedicatedServerConflictRetryDelay(t)
+
+	api := fakeservers.New()
+	defer api.Close()
+
+	server := api.AddServer(fakeservers.Server{Name: "web-1", Status: servers.ServerStatusInstalling})
+	serversService, err := testServersFakeConfig(t, api).GetServersService()
+	require.NoError(t, err)
+
+	err = dedicatedServerRetryOnConflict(context.Background(), serversService, server.ID, 100*time.Millisecond, func() error {
+		_, err := serversService.RestartServer(context.Background(), server.ID)
+		return err
+	})
+	assert.ErrorContains(t, err, "running task")
+}
+
+func TestDedicatedServerV1ConcurrentActionsFake(t *testing.T) {
+	testDedicatedServerConflictRetryDelay(t)
+
+	api := fakeservers.New()
+	defer api.Close()
+
+	ctx := context.Background()
+	meta := testServersFakeConfig(t, api)
+	d := schema.TestResourceDataRaw(t, resourceDedicatedServerV1().Schema, testDedicatedServerV1ReinstallConfig(true, 5, 20, ""))
+	require.False(t, resourceDedicatedServerV1Create(ctx, d, meta).HasError())
+
+	_, next, err := testServersFakePlan(ctx, t, resourceDedicatedServerV1(), d, meta, testDedicatedServerV1ReinstallConfig(true, 10, 20, ""))
+	require.NoError(t, err)
+
+	serversService, err := meta.GetServersService()
+	require.NoError(t, err)
+
+	// Задачи выполняются дольше, чтобы действия пересекались во времени
+	api.AddHook(fakeservers.Hook{Method: http.MethodGet, Path: "task/", Delay: 100 * time.Millisecond})
+
+	serverID := next.Get("server_id").(int)
+
+	var (
+		wg       sync.WaitGroup
+		diags    diag.Diagnostics
+		powerErr error
+	)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		diags = resourceDedicatedServerV1Update(ctx, next, meta)
+	}()
+	go func() {
+		defer wg.Done()
+		// Так действует ресурс питания, знающий только числовой ID
+		defer lockDedicatedServer(strconv.Itoa(serverID))()
+
+		task, err := serversService.StopServer(ctx, serverID)
+		if err == nil {
+			_, err = serversService.WaitForTask(ctx, task.ID)
+		}
+		powerErr = err
+	}()
+	wg.Wait()
+
+	require.False(t, diags.HasError(), diags)
+	require.NoError(t, powerErr)
+
+	// Ни одно действие не было отклонено из-за чужой задачи
+	assert.Len(t, testServersFakeActions(api), 2)
+}
+
+func TestDedicatedServerV1UpdateRetryOnConflictFake(t *testing.T) {
+	testDedicatedServerConflictRetryDelay(t)
+
+	api := fakeservers.New()
+	defer api.Close()
+
+	ctx := context.Background()
+	meta := testServersFakeConfig(t, api)
+	raw := map[string]interface{}{"name": "node-1", "location_id": 1, "root_size": 20}
+	d := schema.TestResourceDataRaw(t, resourceDedicatedServerV1().Schema, raw)
+	require.False(t, resourceDedicatedServerV1Create(ctx, d, meta).HasError())
+
+	raw["labels"] = map[string]interface{}{"env": "prod"}
+	raw["auto_renewal"] = true
+	raw["deletion_protection"] = true
+	_, next, err := testServersFakePlan(ctx, t, resourceDedicatedServerV1(), d, meta, raw)
+	require.NoError(t, err)
+
+	// Каждый вызов аренды один раз отклоняется из-за чужой задачи
+	for _, hook := range []fakeservers.Hook{
+		{Method: http.MethodPatch, Path: "resource/serverchip/billing/" + d.Id()},
+		{Method: http.MethodPut, Path: "resource/serverchip/" + d.Id() + "/labels"},
+		{Method: http.MethodPut, Path: "resource/serverchip/" + d.Id() + "/lock"},
+	} {
+		hook.Status = http.StatusConflict
+		hook.Times = 1
+		api.AddHook(hook)
+	}
+
+	diags := resourceDedicatedServerV1Update(ctx, next, meta)
+	require.False(t, diags.HasError(), diags)
+
+	billing, ok := api.Billing(d.Id())
+	require.True(t, ok)
+	assert.True(t, billing.AutoRenewal)
+	assert.True(t, api.Locked(d.Id()))
+	assert.Equal(t, "prod", next.Get("labels.env"))
+}
+
+func TestDedicatedServerV1DeleteRetryOnConflictFake(t *testing.T) {
+	testDedicatedServerConflictRetryDelay(t)
+
+	api := fakeservers.New()
+	defer api.Close()
+
+	ctx := context.Background()
+	meta := testServersFakeConfig(t, api)
+	raw := map[string]interface{}{"name": "node-1", "location_id": 1, "root_size": 20}
+	d := schema.TestResourceDataRaw(t, resourceDedicatedServerV1().Schema, raw)
+	require.False(t, resourceDedicatedServerV1Create(ctx, d, meta).HasError())
+
+	// Числовой ID сервера неизвестен, поэтому отмена повторяется с паузой
+	require.NoError(t, d.Set("server_id", 0))
+	api.AddHook(fakeservers.Hook{
+		Method: http.MethodDelete,
+		Path:   "resource/serverchip/billing/" + d.Id(),
+		Status: http.StatusConflict,
+		Times:  2,
+	})
+
+	diags := resourceDedicatedServerV1Delete(ctx, d, meta)
+	require.False(t, diags.HasError(), diags)
+
+	billing, ok := api.Billing(d.Id())
+	require.True(t, ok)
+	assert.True(t, billing.CancelAtPeriodEnd)
+}
+
+func TestDedicatedServerRetryOnServerErrorFake(t *testing.T) {
+	testDedicatedServerConflictRetryDelay(t)
+
+	api := fakeservers.New()
+	defer api.Close()
+
+	server := api.AddServer(fakeservers.Server{Name: "web-1", Status: servers.ServerStatusActive})
+	serversService, err := testServersFakeConfig(t, api).GetServersService()
+	require.NoError(t, err)
+
+	// Временная ошибка API повторяется, ошибка запроса — нет
+	api.AddHook(fakeservers.Hook{Method: http.MethodPost, Path: "server/", Status: http.StatusServiceUnavailable, Times: 1})
+	calls := 0
+	err = dedicatedServerRetryOnConflict(context.Background(), serversService, server.ID, time.Minute, func() error {
+		calls++
+		_, err := serversService.StopServer(context.Background(), server.ID)
+		return err
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+
+	api.AddHook(fakeservers.Hook{Method: http.MethodPost, Path: "server/", Status: http.StatusBadRequest, Times: 1})
+	calls = 0
+	err = dedicatedServerRetryOnConflict(context.Background(), serversService, server.ID, time.Minute, func() error {
+		calls++
+		_, err := serversService.StartServer(context.Background(), server.ID)
+		return err
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+}
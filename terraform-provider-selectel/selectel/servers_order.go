@@ -0,0 +1,285 @@
+package selectel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/servers"
+)
+
+// expandDedicatedServerSSHKeys собирает публичные ключи из ssh_keys и
+// зарегистрированные ключи из ssh_key_ids
+func expandDedicatedServerSSHKeys(ctx context.Context, d *schema.ResourceData, serversService servers.ServersAPI) ([]string, error) {
+	var sshKeys []string
+	if v, ok := d.GetOk("ssh_keys"); ok {
+		for _, key := range v.([]interface{}) {
+			sshKeys = append(sshKeys, key.(string))
+		}
+	}
+
+	if v, ok := d.GetOk("ssh_key_ids"); ok {
+		refs := make([]string, len(v.([]interface{})))
+		for i, ref := range v.([]interface{}) {
+			refs[i] = ref.(string)
+		}
+		registeredKeys, err := resolveServerSSHKeys(ctx, serversService, refs)
+		if err != nil {
+			return nil, err
+		}
+		sshKeys = append(sshKeys, registeredKeys...)
+	}
+
+	return sshKeys, nil
+}
+
+// ОС, которая устанавливается на заказанный сервер, если не заданы os_id и
+// os_template_id
+const (
+	serverDefaultOSTemplate = "debian"
+	serverDefaultOSVersion  = "12v2"
+	serverDefaultOSArch     = "x86_64"
+)
+
+// expandDedicatedServerBillingOpts собирает заказ сервера через биллинг из
+// общих аргументов selectel_dedicated_server_v1 и
+// selectel_dedicated_server_group_v1
+func expandDedicatedServerBillingOpts(ctx context.Context, d *schema.ResourceData, config *Config, serversService servers.ServersAPI,
+	name string, sshKeys []string,
+) (*servers.DedicatedServerCreateBilling, error) {
+	// Собираем разметку дисков из raid_type, root_size, swap_size,
+	// custom_partitions, raid_array и volume_group
+	partitionsConfig, err := buildServerPartitionsConfig(expandServerDiskLayout(d))
+	if err != nil {
+		return nil, err
+	}
+
+	locationUUID, err := resolveDedicatedServerLocationUUID(ctx, serversService, d.Get("location_id").(int))
+	if err != nil {
+		return nil, err
+	}
+
+	configID := d.Get("config_id").(int)
+	serviceUUID, err := resolveDedicatedServerServiceUUID(ctx, serversService, locationUUID, configID)
+	if err != nil {
+		return nil, err
+	}
+
+	billingOpts := &servers.DedicatedServerCreateBilling{
+		Name:          name,
+		LocationUUID:  locationUUID,
+		ServiceUUID:   serviceUUID,
+		ConfigID:      configID,
+		PricePlanUUID: d.Get("price_plan_uuid").(string),
+		OSTemplate:    serverDefaultOSTemplate,
+		Arch:          serverDefaultOSArch,
+		Version:       serverDefaultOSVersion,
+		UserHostname:  name,
+		PayCurrency:   "main",
+		UserDesc:      "Terraform managed server",
+		SSHKeys:       sshKeys,
+		Labels:        mergeServerLabels(config.DefaultLabels, expandServerLabels(d.Get("labels"))),
+		Period:        d.Get("billing_period").(string),
+		AutoRenewal:   d.Get("auto_renewal").(bool),
+		UserData:      d.Get("user_data").(string),
+		// Конфигурация разделов, сгенерированная из разметки дисков
+		PartitionsConfig: partitionsConfig,
+	}
+
+	if v, ok := d.GetOk("os_id"); ok {
+		serverOS, err := resolveDedicatedServerOS(ctx, serversService, v.(int))
+		if err != nil {
+			return nil, err
+		}
+
+		billingOpts.OSID = serverOS.ID
+		billingOpts.OSTemplate = serverOSTemplateName(serverOS)
+		if serverOS.Version != "" {
+			billingOpts.Version = serverOS.Version
+		}
+		if serverOS.Architecture != "" {
+			billingOpts.Arch = serverOS.Architecture
+		}
+	}
+
+	if v, ok := d.GetOk("os_template_id"); ok {
+		template, err := serversService.GetOSTemplate(ctx, v.(string))
+		if err != nil {
+			return nil, errGettingObject(objectServerOSTemplate, v.(string), err)
+		}
+
+		billingOpts.CustomTemplateUUID = template.UUID
+		billingOpts.OSTemplate = template.BasedOn
+		if template.Version != "" {
+			billingOpts.Version = template.Version
+		}
+		if template.Arch != "" {
+			billingOpts.Arch = template.Arch
+		}
+	}
+
+	// user_data, ключи и разметка дисков в лог не попадают
+	log.Printf("[DEBUG] Billing order of %s: service %s, location %s, price plan %s, OS %s %s %s, period %s",
+		name, billingOpts.ServiceUUID, billingOpts.LocationUUID, billingOpts.PricePlanUUID,
+		billingOpts.OSTemplate, billingOpts.Version, billingOpts.Arch, billingOpts.Period)
+
+	return billingOpts, nil
+}
+
+// resolveDedicatedServerLocationUUID возвращает UUID локации с числовым ID
+// location_id, который принимает биллинг
+func resolveDedicatedServerLocationUUID(ctx context.Context, serversService servers.ServersAPI, locationID int) (string, error) {
+	locations, err := serversService.ListLocations(ctx)
+	if err != nil {
+		return "", errGettingObjects("server locations", err)
+	}
+
+	for _, location := range locations {
+		if location.LocationID == locationID {
+			return location.UUID, nil
+		}
+	}
+
+	return "", fmt.Errorf("location %d is not found", locationID)
+}
+
+// resolveDedicatedServerServiceUUID возвращает UUID услуги, которой
+// заказывается конфигурация configID в локации. Без config_id услуга
+// определяется, только если локация предлагает одну услугу
+func resolveDedicatedServerServiceUUID(ctx context.Context, serversService servers.ServersAPI, locationUUID string, configID int) (string, error) {
+	availability, err := serversService.ListServerAvailability(ctx, &servers.ServerAvailabilityListOpts{
+		LocationUUID: locationUUID,
+	})
+	if err != nil {
+		return "", errGettingObjects(objectServerAvailability, err)
+	}
+
+	var serviceUUIDs []string
+	for _, av := range availability {
+		if !serverAvailabilityMatchesConfig(av, configID) {
+			continue
+		}
+		if !slices.Contains(serviceUUIDs, av.ServiceUUID) {
+			serviceUUIDs = append(serviceUUIDs, av.ServiceUUID)
+		}
+	}
+
+	switch {
+	case len(serviceUUIDs) == 1:
+		return serviceUUIDs[0], nil
+	case configID != 0 && len(serviceUUIDs) == 0:
+		return "", fmt.Errorf("configuration %d is not offered in location %s", configID, locationUUID)
+	case configID != 0:
+		return "", fmt.Errorf("configuration %d is offered by several services in location %s: %s",
+			configID, locationUUID, strings.Join(serviceUUIDs, ", "))
+	case len(serviceUUIDs) == 0:
+		return "", fmt.Errorf("no servers are offered in location %s", locationUUID)
+	}
+
+	return "", fmt.Errorf("location %s offers several configurations, set config_id to choose one", locationUUID)
+}
+
+// resolveDedicatedServerOS возвращает ОС с ID os_id
+func resolveDedicatedServerOS(ctx context.Context, serversService servers.ServersAPI, osID int) (*servers.ServerOS, error) {
+	operatingSystems, err := serversService.ListOperatingSystems(ctx)
+	if err != nil {
+		return nil, errGettingObjects("server operating systems", err)
+	}
+
+	for _, serverOS := range operatingSystems {
+		if serverOS.ID == osID {
+			return serverOS, nil
+		}
+	}
+
+	return nil, fmt.Errorf("operating system %d is not found", osID)
+}
+
+// resolveDedicatedServerTemplateOS возвращает ОС, на которой основан
+// шаблон установки: совпадают имя шаблона, а также версия и архитектура,
+// если они заданы в шаблоне
+func resolveDedicatedServerTemplateOS(ctx context.Context, serversService servers.ServersAPI, template *servers.ServerOSTemplate) (*servers.ServerOS, error) {
+	operatingSystems, err := serversService.ListOperatingSystems(ctx)
+	if err != nil {
+		return nil, errGettingObjects("server operating systems", err)
+	}
+
+	var matched []*servers.ServerOS
+	for _, serverOS := range operatingSystems {
+		if serverOSTemplateName(serverOS) != strings.ToLower(template.BasedOn) {
+			continue
+		}
+		if template.Version != "" && serverOS.Version != template.Version {
+			continue
+		}
+		if template.Arch != "" && serverOS.Architecture != template.Arch {
+			continue
+		}
+		matched = append(matched, serverOS)
+	}
+
+	switch len(matched) {
+	case 1:
+		return matched[0], nil
+	case 0:
+		return nil, fmt.Errorf("no operating system matches %s %s (%s %s), set os_id",
+			objectServerOSTemplate, template.UUID, template.BasedOn, template.Version)
+	default:
+		return nil, fmt.Errorf("%d operating systems match %s %s (%s %s), set os_id to choose one",
+			len(matched), objectServerOSTemplate, template.UUID, template.BasedOn, template.Version)
+	}
+}
+
+// serverOSTemplateName возвращает имя шаблона установки ОС для заказа
+func serverOSTemplateName(serverOS *servers.ServerOS) string {
+	if serverOS.Distribution != "" {
+		return strings.ToLower(serverOS.Distribution)
+	}
+
+	return strings.ToLower(serverOS.Name)
+}
+
+// placeDedicatedServerOrder размещает заказ через биллинг. С waitForStock
+// заказ размещается только после появления серверов в наличии и
+// повторяется не чаще serverStockPollInterval, если их успели разобрать до
+// заказа, пока не истечет deadline
+func placeDedicatedServerOrder(ctx context.Context, serversService servers.ServersAPI, billingOpts *servers.DedicatedServerCreateBilling,
+	stockRequest serverStockRequest, waitForStock bool, deadline time.Time,
+) (*servers.DedicatedServerCreateResponse, error) {
+	for {
+		if waitForStock {
+			if err := waitForServerStock(ctx, serversService, stockRequest, time.Until(deadline)); err != nil {
+				return nil, err
+			}
+		}
+
+		response, err := serversService.CreateServerResource(ctx, billingOpts)
+		if err == nil {
+			return response, nil
+		}
+		if errors.Is(err, servers.ErrQuotaExceeded) {
+			return nil, fmt.Errorf("the order of %d servers exceeds the servers quota of the account: "+
+				"raise the quota or order fewer servers: %w", max(billingOpts.Quantity, 1), err)
+		}
+		if !waitForStock || !errors.Is(err, servers.ErrOutOfStock) {
+			return nil, err
+		}
+
+		if time.Until(deadline) < serverStockPollInterval {
+			return nil, fmt.Errorf("%s is still out of stock at the end of the create timeout: %w", stockRequest, err)
+		}
+
+		log.Printf("[INFO] %s went out of stock before the order was placed, waiting again", stockRequest)
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(serverStockPollInterval):
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package selectel
+
+import (
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/servers"
+)
+
+// findServerOSTemplate ищет шаблон по UUID, а затем по имени
+func findServerOSTemplate(templates []*servers.ServerOSTemplate, ref string) (*servers.ServerOSTemplate, error) {
+	return findServerObject(templates, objectServerOSTemplate, ref, serverOSTemplateUUID,
+		func(template *servers.ServerOSTemplate) string { return template.Name },
+	)
+}
+
+// findServerOSTemplateByUUID ищет шаблон только по UUID: имя шаблона,
+// совпавшее с переданным UUID, не подходит
+func findServerOSTemplateByUUID(templates []*servers.ServerOSTemplate, uuid string) (*servers.ServerOSTemplate, error) {
+	return findServerObject(templates, objectServerOSTemplate, uuid, serverOSTemplateUUID, nil)
+}
+
+func serverOSTemplateUUID(template *servers.ServerOSTemplate) string {
+	return template.UUID
+}
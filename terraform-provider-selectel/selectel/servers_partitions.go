@@ -0,0 +1,518 @@
+package selectel
+
+import (
+	"context"
+	"crypto/sha1"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Уровни программного RAID в терминах raid_type и raid_array.level
+const (
+	serverRAIDNone = "No RAID"
+	serverRAID0    = "RAID0"
+	serverRAID1    = "RAID1"
+	serverRAID5    = "RAID5"
+	serverRAID6    = "RAID6"
+	serverRAID10   = "RAID10"
+)
+
+// serverDefaultDiskGroup — группа дисков, на которой размещается массив,
+// если disk_group не задан
+const serverDefaultDiskGroup = "SSD SATA"
+
+// serverPartitionRemainingSize означает, что раздел занимает все оставшееся
+// место на дисках массива
+const serverPartitionRemainingSize = -1
+
+// serverPartitionNodeNamespace используется для детерминированных
+// идентификаторов узлов partitions_config
+const serverPartitionNodeNamespace = "terraform-provider-selectel/partitions_config/"
+
+// serverDiskLayoutKeys — аргументы, из которых собирается разметка дисков
+var serverDiskLayoutKeys = []string{"raid_type", "raid_array", "volume_group", "swap_size", "root_size", "custom_partitions"}
+
+// serverLVMNameRegexp ограничивает имена групп томов, пулов и томов LVM
+var serverLVMNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9+_.][a-zA-Z0-9+_.-]{0,126}$`)
+
+// serverRAIDLevel описывает ограничения уровня mdraid
+type serverRAIDLevel struct {
+	// MDLevel — значение level в узле soft_raid
+	MDLevel    string
+	MinMembers int
+	// EvenMembers требует четное число дисков
+	EvenMembers bool
+}
+
+var serverRAIDLevels = map[string]serverRAIDLevel{
+	serverRAIDNone: {MinMembers: 1},
+	serverRAID0:    {MDLevel: "raid0", MinMembers: 2},
+	serverRAID1:    {MDLevel: "raid1", MinMembers: 2},
+	serverRAID5:    {MDLevel: "raid5", MinMembers: 3},
+	serverRAID6:    {MDLevel: "raid6", MinMembers: 4},
+	serverRAID10:   {MDLevel: "raid10", MinMembers: 4, EvenMembers: true},
+}
+
+func serverRAIDLevelNames() []string {
+	return []string{serverRAIDNone, serverRAID0, serverRAID1, serverRAID5, serverRAID6, serverRAID10}
+}
+
+// serverDiskLayout описывает разметку дисков сервера. Первый массив
+// содержит системные разделы /boot, swap и /
+type serverDiskLayout struct {
+	Arrays       []serverRAIDArray
+	VolumeGroups []serverVolumeGroup
+}
+
+// serverRAIDArray описывает один программный массив
+type serverRAIDArray struct {
+	Level      string
+	Members    int
+	DiskGroup  string
+	DiskSize   int
+	Partitions []serverPartition
+}
+
+// serverPartition описывает раздел, создаваемый на каждом диске массива.
+// Раздел с VolumeGroup становится физическим томом LVM вместо файловой
+// системы
+type serverPartition struct {
+	Mount       string
+	FSType      string
+	Size        int
+	VolumeGroup string
+}
+
+// serverVolumeGroup описывает группу томов LVM
+type serverVolumeGroup struct {
+	Name           string
+	ThinPools      []serverThinPool
+	LogicalVolumes []serverLogicalVolume
+}
+
+// serverThinPool описывает тонкий пул внутри группы томов
+type serverThinPool struct {
+	Name string
+	Size int
+}
+
+// serverLogicalVolume описывает логический том с файловой системой. Том с
+// ThinPool создается в тонком пуле
+type serverLogicalVolume struct {
+	Name     string
+	Mount    string
+	FSType   string
+	Size     int
+	ThinPool string
+}
+
+// serverResourceGetter позволяет читать разметку как из ResourceData, так и
+// из ResourceDiff
+type serverResourceGetter interface {
+	Get(key string) interface{}
+}
+
+// expandServerDiskLayout собирает разметку из raid_type, swap_size,
+// root_size, custom_partitions, raid_array и volume_group
+func expandServerDiskLayout(d serverResourceGetter) *serverDiskLayout {
+	var arrays []serverRAIDArray
+	for _, raw := range d.Get("raid_array").([]interface{}) {
+		array, _ := raw.(map[string]interface{})
+		if array == nil {
+			continue
+		}
+		arrays = append(arrays, serverRAIDArray{
+			Level:      array["level"].(string),
+			Members:    array["members"].(int),
+			DiskGroup:  array["disk_group"].(string),
+			DiskSize:   array["disk_size"].(int),
+			Partitions: expandServerPartitions(array["partition"].([]interface{})),
+		})
+	}
+
+	if len(arrays) == 0 {
+		arrays = []serverRAIDArray{{Level: d.Get("raid_type").(string)}}
+	}
+
+	system := []serverPartition{
+		{Mount: "/boot", FSType: "ext4", Size: 1},
+	}
+	if swapSize := d.Get("swap_size").(int); swapSize > 0 {
+		system = append(system, serverPartition{Mount: "swap", FSType: "swap", Size: swapSize})
+	}
+	system = append(system, serverPartition{Mount: "/", FSType: "ext4", Size: d.Get("root_size").(int)})
+	system = append(system, expandServerPartitions(d.Get("custom_partitions").([]interface{}))...)
+	arrays[0].Partitions = append(system, arrays[0].Partitions...)
+
+	for i := range arrays {
+		if arrays[i].DiskGroup == "" {
+			arrays[i].DiskGroup = serverDefaultDiskGroup
+		}
+		if arrays[i].Members == 0 {
+			arrays[i].Members = serverRAIDLevels[arrays[i].Level].MinMembers
+		}
+	}
+
+	return &serverDiskLayout{
+		Arrays:       arrays,
+		VolumeGroups: expandServerVolumeGroups(d.Get("volume_group").([]interface{})),
+	}
+}
+
+func expandServerVolumeGroups(v []interface{}) []serverVolumeGroup {
+	volumeGroups := make([]serverVolumeGroup, 0, len(v))
+	for _, raw := range v {
+		volumeGroup, _ := raw.(map[string]interface{})
+		if volumeGroup == nil {
+			continue
+		}
+
+		var thinPools []serverThinPool
+		for _, raw := range volumeGroup["thin_pool"].([]interface{}) {
+			thinPool := raw.(map[string]interface{})
+			thinPools = append(thinPools, serverThinPool{
+				Name: thinPool["name"].(string),
+				Size: thinPool["size"].(int),
+			})
+		}
+
+		var logicalVolumes []serverLogicalVolume
+		for _, raw := range volumeGroup["logical_volume"].([]interface{}) {
+			logicalVolume := raw.(map[string]interface{})
+			fstype := "ext4"
+			if v, ok := logicalVolume["fstype"].(string); ok && v != "" {
+				fstype = v
+			}
+			logicalVolumes = append(logicalVolumes, serverLogicalVolume{
+				Name:     logicalVolume["name"].(string),
+				Mount:    logicalVolume["mount"].(string),
+				FSType:   fstype,
+				Size:     logicalVolume["size"].(int),
+				ThinPool: logicalVolume["thin_pool"].(string),
+			})
+		}
+
+		volumeGroups = append(volumeGroups, serverVolumeGroup{
+			Name:           volumeGroup["name"].(string),
+			ThinPools:      thinPools,
+			LogicalVolumes: logicalVolumes,
+		})
+	}
+
+	return volumeGroups
+}
+
+func expandServerPartitions(v []interface{}) []serverPartition {
+	partitions := make([]serverPartition, 0, len(v))
+	for _, raw := range v {
+		partition := raw.(map[string]interface{})
+		fstype := "ext4"
+		if v, ok := partition["fstype"].(string); ok && v != "" {
+			fstype = v
+		}
+		volumeGroup, _ := partition["volume_group"].(string)
+		partitions = append(partitions, serverPartition{
+			Mount:       partition["mount"].(string),
+			FSType:      fstype,
+			Size:        partition["size"].(int),
+			VolumeGroup: volumeGroup,
+		})
+	}
+
+	return partitions
+}
+
+// validateServerDiskLayout проверяет число дисков в массивах, размеры,
+// группы томов и уникальность точек монтирования
+func validateServerDiskLayout(layout *serverDiskLayout) error {
+	mounts := make(map[string]struct{})
+	physicalVolumes := make(map[string]int)
+	for i, array := range layout.Arrays {
+		level, ok := serverRAIDLevels[array.Level]
+		if !ok {
+			return fmt.Errorf("raid array %d: unsupported level %q", i, array.Level)
+		}
+		if array.Level == serverRAIDNone && array.Members != 1 {
+			return fmt.Errorf("raid array %d: %q requires exactly one disk, got %d", i, array.Level, array.Members)
+		}
+		if array.Members < level.MinMembers {
+			return fmt.Errorf("raid array %d: %s requires at least %d disks, got %d", i, array.Level, level.MinMembers, array.Members)
+		}
+		if level.EvenMembers && array.Members%2 != 0 {
+			return fmt.Errorf("raid array %d: %s requires an even number of disks, got %d", i, array.Level, array.Members)
+		}
+		if len(array.Partitions) == 0 {
+			return fmt.Errorf("raid array %d: at least one partition is required", i)
+		}
+
+		for j, partition := range array.Partitions {
+			switch {
+			case partition.Size == serverPartitionRemainingSize && j != len(array.Partitions)-1:
+				return fmt.Errorf("raid array %d: only the last partition can use the remaining space, %q is not last", i, partition.Mount)
+			case partition.Size == 0 || partition.Size < serverPartitionRemainingSize:
+				return fmt.Errorf("raid array %d: partition %q has invalid size %d", i, partition.Mount, partition.Size)
+			}
+
+			if partition.VolumeGroup != "" {
+				if partition.Mount != "" {
+					return fmt.Errorf("raid array %d: partition of volume group %q can't have a mount point", i, partition.VolumeGroup)
+				}
+				physicalVolumes[partition.VolumeGroup]++
+				continue
+			}
+			if partition.Mount == "" {
+				return fmt.Errorf("raid array %d: partition %d needs either a mount point or a volume group", i, j)
+			}
+
+			if _, ok := mounts[partition.Mount]; ok {
+				return fmt.Errorf("raid array %d: mount point %q is used more than once", i, partition.Mount)
+			}
+			mounts[partition.Mount] = struct{}{}
+		}
+	}
+
+	volumeGroups := make(map[string]struct{}, len(layout.VolumeGroups))
+	for _, volumeGroup := range layout.VolumeGroups {
+		if _, ok := volumeGroups[volumeGroup.Name]; ok {
+			return fmt.Errorf("volume group %q is declared more than once", volumeGroup.Name)
+		}
+		volumeGroups[volumeGroup.Name] = struct{}{}
+
+		if physicalVolumes[volumeGroup.Name] == 0 {
+			return fmt.Errorf("volume group %q has no partitions, set volume_group on a partition", volumeGroup.Name)
+		}
+		if err := validateServerVolumeGroup(volumeGroup, mounts); err != nil {
+			return err
+		}
+	}
+
+	for name := range physicalVolumes {
+		if _, ok := volumeGroups[name]; !ok {
+			return fmt.Errorf("volume group %q is not declared", name)
+		}
+	}
+
+	return nil
+}
+
+// validateServerVolumeGroup проверяет тома и тонкие пулы одной группы. Все
+// оставшееся место группы может занять только один том или пул
+func validateServerVolumeGroup(volumeGroup serverVolumeGroup, mounts map[string]struct{}) error {
+	names := make(map[string]struct{})
+	thinPools := make(map[string]struct{}, len(volumeGroup.ThinPools))
+	remaining := 0
+
+	for _, thinPool := range volumeGroup.ThinPools {
+		if _, ok := names[thinPool.Name]; ok {
+			return fmt.Errorf("volume group %q: name %q is used more than once", volumeGroup.Name, thinPool.Name)
+		}
+		names[thinPool.Name] = struct{}{}
+		thinPools[thinPool.Name] = struct{}{}
+
+		switch {
+		case thinPool.Size == serverPartitionRemainingSize:
+			remaining++
+		case thinPool.Size <= 0:
+			return fmt.Errorf("volume group %q: thin pool %q has invalid size %d", volumeGroup.Name, thinPool.Name, thinPool.Size)
+		}
+	}
+
+	for _, logicalVolume := range volumeGroup.LogicalVolumes {
+		if _, ok := names[logicalVolume.Name]; ok {
+			return fmt.Errorf("volume group %q: name %q is used more than once", volumeGroup.Name, logicalVolume.Name)
+		}
+		names[logicalVolume.Name] = struct{}{}
+
+		if logicalVolume.ThinPool != "" {
+			if _, ok := thinPools[logicalVolume.ThinPool]; !ok {
+				return fmt.Errorf("volume group %q: logical volume %q refers to unknown thin pool %q",
+					volumeGroup.Name, logicalVolume.Name, logicalVolume.ThinPool)
+			}
+			// Тонкие тома могут превышать размер пула, но их размер
+			// должен быть задан явно
+			if logicalVolume.Size <= 0 {
+				return fmt.Errorf("volume group %q: thin logical volume %q needs an explicit size",
+					volumeGroup.Name, logicalVolume.Name)
+			}
+		} else {
+			switch {
+			case logicalVolume.Size == serverPartitionRemainingSize:
+				remaining++
+			case logicalVolume.Size <= 0:
+				return fmt.Errorf("volume group %q: logical volume %q has invalid size %d",
+					volumeGroup.Name, logicalVolume.Name, logicalVolume.Size)
+			}
+		}
+
+		if _, ok := mounts[logicalVolume.Mount]; ok {
+			return fmt.Errorf("volume group %q: mount point %q is used more than once", volumeGroup.Name, logicalVolume.Mount)
+		}
+		mounts[logicalVolume.Mount] = struct{}{}
+	}
+
+	if remaining > 1 {
+		return fmt.Errorf("volume group %q: only one logical volume or thin pool can use the remaining space", volumeGroup.Name)
+	}
+
+	return nil
+}
+
+// buildServerPartitionsConfig генерирует partitions_config в формате панели
+// управления: объект, где ключ — идентификатор узла, а значение — диск,
+// раздел, программный массив или файловая система. Идентификаторы
+// детерминированы, поэтому повторная генерация дает тот же результат
+func buildServerPartitionsConfig(layout *serverDiskLayout) (map[string]interface{}, error) {
+	if err := validateServerDiskLayout(layout); err != nil {
+		return nil, err
+	}
+
+	nodes := make(map[string]interface{})
+	usedDisks := make(map[string]int)
+	physicalVolumes := make(map[string][]string)
+
+	for i, array := range layout.Arrays {
+		disks := make([]string, array.Members)
+		for m := range disks {
+			disks[m] = serverPartitionNodeID("disk/%s/%d", array.DiskGroup, usedDisks[array.DiskGroup])
+			usedDisks[array.DiskGroup]++
+
+			match := map[string]interface{}{
+				"type": array.DiskGroup,
+			}
+			if array.DiskSize > 0 {
+				match["size"] = array.DiskSize
+			}
+			nodes[disks[m]] = map[string]interface{}{
+				"type":  "local_drive",
+				"match": match,
+			}
+		}
+
+		for j, partition := range array.Partitions {
+			members := make([]string, len(disks))
+			for m, disk := range disks {
+				members[m] = serverPartitionNodeID("array/%d/partition/%d/disk/%d", i, j, m)
+				nodes[members[m]] = map[string]interface{}{
+					"type":     "partition",
+					"device":   disk,
+					"priority": j,
+					"size":     partition.Size,
+				}
+			}
+
+			device := members[0]
+			if array.Level != serverRAIDNone {
+				// Загрузчик читает /boot с любого диска, поэтому он всегда
+				// зеркалируется независимо от уровня массива
+				mdLevel := serverRAIDLevels[array.Level].MDLevel
+				if partition.Mount == "/boot" {
+					mdLevel = serverRAIDLevels[serverRAID1].MDLevel
+				}
+
+				device = serverPartitionNodeID("array/%d/raid/%d", i, j)
+				nodes[device] = map[string]interface{}{
+					"type":    "soft_raid",
+					"level":   mdLevel,
+					"members": members,
+				}
+			}
+
+			if partition.VolumeGroup != "" {
+				physicalVolumes[partition.VolumeGroup] = append(physicalVolumes[partition.VolumeGroup], device)
+				continue
+			}
+
+			nodes[serverPartitionNodeID("array/%d/filesystem/%d", i, j)] = map[string]interface{}{
+				"type":   "filesystem",
+				"fstype": partition.FSType,
+				"device": device,
+				"mount":  partition.Mount,
+			}
+		}
+	}
+
+	for _, volumeGroup := range layout.VolumeGroups {
+		addServerVolumeGroupNodes(nodes, volumeGroup, physicalVolumes[volumeGroup.Name])
+	}
+
+	return nodes, nil
+}
+
+// addServerVolumeGroupNodes добавляет узлы группы томов, тонких пулов,
+// логических томов и их файловых систем. Том или пул, занимающий оставшееся
+// место, получает наибольший приоритет, чтобы создаваться последним
+func addServerVolumeGroupNodes(nodes map[string]interface{}, volumeGroup serverVolumeGroup, members []string) {
+	vgID := serverPartitionNodeID("vg/%s", volumeGroup.Name)
+	nodes[vgID] = map[string]interface{}{
+		"type":    "lvm_vg",
+		"name":    volumeGroup.Name,
+		"members": members,
+	}
+
+	last := len(volumeGroup.ThinPools) + len(volumeGroup.LogicalVolumes)
+	priority := func(index, size int) int {
+		if size == serverPartitionRemainingSize {
+			return last
+		}
+		return index
+	}
+
+	thinPoolIDs := make(map[string]string, len(volumeGroup.ThinPools))
+	for i, thinPool := range volumeGroup.ThinPools {
+		thinPoolIDs[thinPool.Name] = serverPartitionNodeID("vg/%s/thin_pool/%s", volumeGroup.Name, thinPool.Name)
+		nodes[thinPoolIDs[thinPool.Name]] = map[string]interface{}{
+			"type":     "lvm_thinpool",
+			"name":     thinPool.Name,
+			"device":   vgID,
+			"priority": priority(i, thinPool.Size),
+			"size":     thinPool.Size,
+		}
+	}
+
+	for i, logicalVolume := range volumeGroup.LogicalVolumes {
+		device := vgID
+		if logicalVolume.ThinPool != "" {
+			device = thinPoolIDs[logicalVolume.ThinPool]
+		}
+
+		lvID := serverPartitionNodeID("vg/%s/lv/%s", volumeGroup.Name, logicalVolume.Name)
+		nodes[lvID] = map[string]interface{}{
+			"type":     "lvm_lv",
+			"name":     logicalVolume.Name,
+			"device":   device,
+			"priority": priority(len(volumeGroup.ThinPools)+i, logicalVolume.Size),
+			"size":     logicalVolume.Size,
+		}
+		nodes[serverPartitionNodeID("vg/%s/lv/%s/filesystem", volumeGroup.Name, logicalVolume.Name)] = map[string]interface{}{
+			"type":   "filesystem",
+			"fstype": logicalVolume.FSType,
+			"device": lvID,
+			"mount":  logicalVolume.Mount,
+		}
+	}
+}
+
+// serverPartitionNodeID возвращает UUID версии 5 для пути узла
+func serverPartitionNodeID(format string, args ...interface{}) string {
+	sum := sha1.Sum([]byte(serverPartitionNodeNamespace + fmt.Sprintf(format, args...)))
+	sum[6] = (sum[6] & 0x0f) | 0x50
+	sum[8] = (sum[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
+
+// dedicatedServerV1DiskLayoutCustomizeDiff проверяет разметку на этапе plan
+func dedicatedServerV1DiskLayoutCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() != "" && !d.HasChanges(serverDiskLayoutKeys...) {
+		return nil
+	}
+	for _, key := range serverDiskLayoutKeys {
+		if !d.NewValueKnown(key) {
+			return nil
+		}
+	}
+
+	return validateServerDiskLayout(expandServerDiskLayout(d))
+}
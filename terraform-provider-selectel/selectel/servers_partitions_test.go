@@ -0,0 +1,257 @@
+package selectel
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/fakeservers"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files in testdata")
+
+func TestBuildServerPartitionsConfigGolden(t *testing.T) {
+	testCases := []struct {
+		name string
+		raw  map[string]interface{}
+	}{
+		{
+			name: "legacy_raid1",
+			raw: map[string]interface{}{
+				"root_size": 20,
+				"custom_partitions": []interface{}{
+					map[string]interface{}{"mount": "/var", "fstype": "xfs", "size": 10},
+				},
+			},
+		},
+		{
+			name: "no_raid",
+			raw: map[string]interface{}{
+				"raid_type": "No RAID",
+				"swap_size": 0,
+				"root_size": 50,
+			},
+		},
+		{
+			name: "raid5",
+			raw: map[string]interface{}{
+				"raid_type": "RAID5",
+				"root_size": 100,
+			},
+		},
+		{
+			name: "storage_node",
+			raw: map[string]interface{}{
+				"root_size": 50,
+				"raid_array": []interface{}{
+					map[string]interface{}{
+						"level":      "RAID1",
+						"disk_group": "SSD SATA",
+						"disk_size":  480,
+					},
+					map[string]interface{}{
+						"level":      "RAID10",
+						"members":    6,
+						"disk_group": "HDD SATA",
+						"partition": []interface{}{
+							map[string]interface{}{"mount": "/data", "fstype": "xfs", "size": -1},
+						},
+					},
+					map[string]interface{}{
+						"level":      "RAID6",
+						"members":    4,
+						"disk_group": "HDD SATA",
+						"partition": []interface{}{
+							map[string]interface{}{"mount": "/backup", "size": -1},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "lvm_thin_pool",
+			raw: map[string]interface{}{
+				"root_size": 30,
+				"raid_array": []interface{}{
+					map[string]interface{}{
+						"level": "RAID1",
+						"partition": []interface{}{
+							map[string]interface{}{"volume_group": "system", "size": 200},
+						},
+					},
+					map[string]interface{}{
+						"level":      "RAID10",
+						"disk_group": "HDD SATA",
+						"partition": []interface{}{
+							map[string]interface{}{"volume_group": "data", "size": -1},
+						},
+					},
+				},
+				"volume_group": []interface{}{
+					map[string]interface{}{
+						"name": "system",
+						"logical_volume": []interface{}{
+							map[string]interface{}{"name": "docker", "mount": "/var/lib/docker", "fstype": "xfs", "size": 100},
+							map[string]interface{}{"name": "log", "mount": "/var/log", "size": -1},
+						},
+					},
+					map[string]interface{}{
+						"name": "data",
+						"thin_pool": []interface{}{
+							map[string]interface{}{"name": "pool", "size": -1},
+						},
+						"logical_volume": []interface{}{
+							map[string]interface{}{"name": "data", "mount": "/data", "fstype": "xfs", "size": 4000, "thin_pool": "pool"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceDedicatedServerV1().Schema, testCase.raw)
+
+			config, err := buildServerPartitionsConfig(expandServerDiskLayout(d))
+			require.NoError(t, err)
+
+			actual, err := json.MarshalIndent(config, "", "  ")
+			require.NoError(t, err)
+			actual = append(actual, '\n')
+
+			golden := filepath.Join("testdata", "partitions_config", testCase.name+".json")
+			if *updateGolden {
+				require.NoError(t, os.MkdirAll(filepath.Dir(golden), 0o755))
+				require.NoError(t, os.WriteFile(golden, actual, 0o600))
+			}
+
+			expected, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), string(actual))
+		})
+	}
+}
+
+func TestValidateServerDiskLayout(t *testing.T) {
+	system := []serverPartition{{Mount: "/", FSType: "ext4", Size: 20}}
+
+	testCases := []struct {
+		name   string
+		layout serverDiskLayout
+		valid  bool
+	}{
+		{"raid10 with four disks", serverDiskLayout{Arrays: []serverRAIDArray{{Level: serverRAID10, Members: 4, Partitions: system}}}, true},
+		{"raid10 with odd disks", serverDiskLayout{Arrays: []serverRAIDArray{{Level: serverRAID10, Members: 5, Partitions: system}}}, false},
+		{"raid6 with three disks", serverDiskLayout{Arrays: []serverRAIDArray{{Level: serverRAID6, Members: 3, Partitions: system}}}, false},
+		{"raid5 with three disks", serverDiskLayout{Arrays: []serverRAIDArray{{Level: serverRAID5, Members: 3, Partitions: system}}}, true},
+		{"no raid with two disks", serverDiskLayout{Arrays: []serverRAIDArray{{Level: serverRAIDNone, Members: 2, Partitions: system}}}, false},
+		{"unknown level", serverDiskLayout{Arrays: []serverRAIDArray{{Level: "RAID50", Members: 8, Partitions: system}}}, false},
+		{"array without partitions", serverDiskLayout{Arrays: []serverRAIDArray{
+			{Level: serverRAID1, Members: 2, Partitions: system},
+			{Level: serverRAID1, Members: 2},
+		}}, false},
+		{"remaining space not last", serverDiskLayout{Arrays: []serverRAIDArray{{Level: serverRAID1, Members: 2, Partitions: []serverPartition{
+			{Mount: "/", Size: -1},
+			{Mount: "/var", Size: 10},
+		}}}}, false},
+		{"duplicate mount", serverDiskLayout{Arrays: []serverRAIDArray{
+			{Level: serverRAID1, Members: 2, Partitions: system},
+			{Level: serverRAID0, Members: 2, Partitions: system},
+		}}, false},
+		{"volume group", serverDiskLayout{
+			Arrays: []serverRAIDArray{{Level: serverRAID1, Members: 2, Partitions: append(system, serverPartition{VolumeGroup: "vg0", Size: -1})}},
+			VolumeGroups: []serverVolumeGroup{{
+				Name:           "vg0",
+				ThinPools:      []serverThinPool{{Name: "pool", Size: 100}},
+				LogicalVolumes: []serverLogicalVolume{{Name: "data", Mount: "/data", Size: 500, ThinPool: "pool"}, {Name: "log", Mount: "/var/log", Size: -1}},
+			}},
+		}, true},
+		{"undeclared volume group", serverDiskLayout{
+			Arrays: []serverRAIDArray{{Level: serverRAID1, Members: 2, Partitions: append(system, serverPartition{VolumeGroup: "vg0", Size: 10})}},
+		}, false},
+		{"volume group without partitions", serverDiskLayout{
+			Arrays:       []serverRAIDArray{{Level: serverRAID1, Members: 2, Partitions: system}},
+			VolumeGroups: []serverVolumeGroup{{Name: "vg0"}},
+		}, false},
+		{"unknown thin pool", serverDiskLayout{
+			Arrays: []serverRAIDArray{{Level: serverRAID1, Members: 2, Partitions: append(system, serverPartition{VolumeGroup: "vg0", Size: 10})}},
+			VolumeGroups: []serverVolumeGroup{{
+				Name:           "vg0",
+				LogicalVolumes: []serverLogicalVolume{{Name: "data", Mount: "/data", Size: 5, ThinPool: "pool"}},
+			}},
+		}, false},
+		{"two volumes use remaining space", serverDiskLayout{
+			Arrays: []serverRAIDArray{{Level: serverRAID1, Members: 2, Partitions: append(system, serverPartition{VolumeGroup: "vg0", Size: 10})}},
+			VolumeGroups: []serverVolumeGroup{{
+				Name:           "vg0",
+				ThinPools:      []serverThinPool{{Name: "pool", Size: -1}},
+				LogicalVolumes: []serverLogicalVolume{{Name: "data", Mount: "/data", Size: -1}},
+			}},
+		}, false},
+		{"logical volume reuses mount", serverDiskLayout{
+			Arrays: []serverRAIDArray{{Level: serverRAID1, Members: 2, Partitions: append(system, serverPartition{VolumeGroup: "vg0", Size: 10})}},
+			VolumeGroups: []serverVolumeGroup{{
+				Name:           "vg0",
+				LogicalVolumes: []serverLogicalVolume{{Name: "root", Mount: "/", Size: 5}},
+			}},
+		}, false},
+		{"partition without mount", serverDiskLayout{
+			Arrays: []serverRAIDArray{{Level: serverRAID1, Members: 2, Partitions: append(system, serverPartition{Size: 10})}},
+		}, false},
+	}
+
+	for _, testCase := range testCases {
+		err := validateServerDiskLayout(&testCase.layout)
+		if testCase.valid {
+			assert.NoError(t, err, testCase.name)
+		} else {
+			assert.Error(t, err, testCase.name)
+		}
+	}
+}
+
+func TestDedicatedServerV1CreateRAIDArraysFake(t *testing.T) {
+	api := fakeservers.New()
+	defer api.Close()
+
+	ctx := context.Background()
+	meta := testServersFakeConfig(t, api)
+	d := schema.TestResourceDataRaw(t, resourceDedicatedServerV1().Schema, map[string]interface{}{
+		"name":        "storage-1",
+		"location_id": 1,
+		"root_size":   50,
+		"raid_array": []interface{}{
+			map[string]interface{}{"level": "RAID1"},
+			map[string]interface{}{
+				"level":      "RAID10",
+				"members":    4,
+				"disk_group": "HDD SATA",
+				"partition": []interface{}{
+					map[string]interface{}{"mount": "/data", "size": -1},
+				},
+			},
+		},
+	})
+
+	diags := resourceDedicatedServerV1Create(ctx, d, meta)
+	require.False(t, diags.HasError(), diags)
+
+	orders := api.Orders()
+	require.Len(t, orders, 1)
+
+	levels := map[string]int{}
+	for _, node := range orders[0]["partitions_config"].(map[string]interface{}) {
+		node := node.(map[string]interface{})
+		if node["type"] == "soft_raid" {
+			levels[node["level"].(string)]++
+		}
+	}
+	assert.Equal(t, map[string]int{"raid1": 3, "raid10": 1}, levels)
+}
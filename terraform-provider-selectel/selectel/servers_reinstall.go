@@ -0,0 +1,152 @@
+package selectel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/servers"
+)
+
+// dedicatedServerV1ReinstallKeys — аргументы, изменение которых требует
+// установки ОС заново: новым сервером или переустановкой с
+// reinstall_on_change
+var dedicatedServerV1ReinstallKeys = append([]string{
+	"os_id", "os_template_id", "user_data",
+}, serverDiskLayoutKeys...)
+
+// dedicatedServerV1InstallOnlyKeys — ключи доступа, которые записываются на
+// сервер только при установке ОС. С reinstall_on_change их изменение
+// переустанавливает ОС, без него не пересоздает сервер: новые ключи попадут
+// на сервер при следующей установке
+var dedicatedServerV1InstallOnlyKeys = []string{"ssh_keys", "ssh_key_ids"}
+
+// dedicatedServerV1NeedsReinstall сообщает, требуют ли изменения
+// переустановки ОС при reinstall_on_change
+func dedicatedServerV1NeedsReinstall(d *schema.ResourceData) bool {
+	if d.HasChanges(dedicatedServerV1ReinstallKeys...) {
+		return true
+	}
+
+	return d.Get("reinstall_on_change").(bool) && d.HasChanges(dedicatedServerV1InstallOnlyKeys...)
+}
+
+// dedicatedServerV1SSHKeysWarning предупреждает, что измененные ключи не
+// попали на работающий сервер
+func dedicatedServerV1SSHKeysWarning() diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  "SSH keys are not changed on the running server",
+		Detail: "ssh_keys and ssh_key_ids are written to the server only when the OS is installed. " +
+			"The new keys will be used at the next reinstall; add them to the server manually to use them now.",
+	}
+}
+
+// dedicatedServerV1ReinstallCustomizeDiff пересоздает сервер при изменении
+// ОС, user_data или разметки, если не включен reinstall_on_change. Ключи
+// доступа сервер не пересоздают
+func dedicatedServerV1ReinstallCustomizeDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || d.Get("reinstall_on_change").(bool) {
+		return nil
+	}
+
+	for _, key := range dedicatedServerV1ReinstallKeys {
+		if !d.HasChange(key) {
+			continue
+		}
+		if err := d.ForceNew(key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// dedicatedServerV1NumericID возвращает числовой ID сервера для действий
+// над ним: переустановки и управления питанием. Серверы, заказанные через
+// биллинг, хранят его в server_id
+func dedicatedServerV1NumericID(d *schema.ResourceData) (int, error) {
+	if len(d.Id()) <= 10 {
+		return strconv.Atoi(d.Id())
+	}
+
+	serverID := d.Get("server_id").(int)
+	if serverID == 0 {
+		return 0, fmt.Errorf("numeric ID of %s %s is unknown", objectDedicatedServer, d.Id())
+	}
+
+	return serverID, nil
+}
+
+// dedicatedServerV1Reinstall переустанавливает ОС на том же сервере с
+// текущими ОС, ключами, user_data и разметкой и ожидает завершения задачи
+func dedicatedServerV1Reinstall(ctx context.Context, d *schema.ResourceData, serversService servers.ServersAPI, timeout time.Duration) error {
+	serverID, err := dedicatedServerV1NumericID(d)
+	if err != nil {
+		return fmt.Errorf("%w, set reinstall_on_change = false to replace the server instead", err)
+	}
+
+	partitionsConfig, err := buildServerPartitionsConfig(expandServerDiskLayout(d))
+	if err != nil {
+		return err
+	}
+
+	sshKeys, err := expandDedicatedServerSSHKeys(ctx, d, serversService)
+	if err != nil {
+		return err
+	}
+
+	reinstallOpts := &servers.ServerReinstallOpts{
+		OSID:             d.Get("os_id").(int),
+		SSHKeys:          sshKeys,
+		UserData:         d.Get("user_data").(string),
+		PartitionsConfig: partitionsConfig,
+	}
+
+	if v, ok := d.GetOk("os_template_id"); ok {
+		template, err := serversService.GetOSTemplate(ctx, v.(string))
+		if err != nil {
+			return errGettingObject(objectServerOSTemplate, v.(string), err)
+		}
+		reinstallOpts.CustomTemplateUUID = template.UUID
+
+		// Без os_id устанавливается ОС, на которой основан шаблон
+		if reinstallOpts.OSID == 0 {
+			serverOS, err := resolveDedicatedServerTemplateOS(ctx, serversService, template)
+			if err != nil {
+				return err
+			}
+			reinstallOpts.OSID = serverOS.ID
+		}
+	}
+
+	if reinstallOpts.OSID == 0 {
+		return errors.New("os_id or os_template_id is required to reinstall the server")
+	}
+
+	log.Printf("[DEBUG] Reinstalling %s %d with OS %d", objectDedicatedServer, serverID, reinstallOpts.OSID)
+
+	var task *servers.ServerTaskStatus
+	err = dedicatedServerRetryOnConflict(ctx, serversService, serverID, timeout, func() (err error) {
+		task, err = serversService.ReinstallServer(ctx, serverID, reinstallOpts)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("error reinstalling server %d: %w", serverID, err)
+	}
+
+	if task != nil && task.ID != 0 {
+		taskCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		if _, err := serversService.WaitForTask(taskCtx, task.ID); err != nil {
+			return fmt.Errorf("error waiting for reinstall task %d: %w", task.ID, err)
+		}
+	}
+
+	return nil
+}
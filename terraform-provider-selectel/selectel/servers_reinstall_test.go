@@ -0,0 +1,225 @@
+package selectel
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/fakeservers"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/servers"
+)
+
+func testDedicatedServerV1ReinstallConfig(reinstallOnChange bool, osID, rootSize int, userData string) map[string]interface{} {
+	return map[string]interface{}{
+		"name":                "db-1",
+		"location_id":         1,
+		"os_id":               osID,
+		"root_size":           rootSize,
+		"user_data":           userData,
+		"reinstall_on_change": reinstallOnChange,
+	}
+}
+
+func TestDedicatedServerV1ReinstallOnChangeFake(t *testing.T) {
+	api := fakeservers.New()
+	defer api.Close()
+
+	ctx := context.Background()
+	meta := testServersFakeConfig(t, api)
+	d := schema.TestResourceDataRaw(t, resourceDedicatedServerV1().Schema, testDedicatedServerV1ReinstallConfig(true, 5, 20, "#cloud-config\n"))
+	require.False(t, resourceDedicatedServerV1Create(ctx, d, meta).HasError())
+
+	serverUUID := d.Id()
+	server, ok := api.Server(serverUUID)
+	require.True(t, ok)
+	assert.Equal(t, server.ID, d.Get("server_id"))
+	assert.Equal(t, "#cloud-config\n", api.Orders()[0]["user_data"])
+
+	diff, next, err := testServersFakePlan(ctx, t, resourceDedicatedServerV1(), d, meta,
+		testDedicatedServerV1ReinstallConfig(true, 10, 30, "#cloud-config\npackages: [nginx]\n"))
+	require.NoError(t, err)
+	assert.False(t, diff.RequiresNew())
+
+	diags := resourceDedicatedServerV1Update(ctx, next, meta)
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, serverUUID, next.Id())
+	assert.Len(t, api.Orders(), 1)
+
+	var action struct {
+		Action string `json:"action"`
+		Params struct {
+			OSID             int                    `json:"os_id"`
+			UserData         string                 `json:"user_data"`
+			PartitionsConfig map[string]interface{} `json:"partitions_config"`
+		} `json:"params"`
+	}
+	var found bool
+	for _, request := range api.Requests() {
+		if request.Method == http.MethodPost && strings.HasSuffix(request.Path, "/action") {
+			require.NoError(t, json.Unmarshal([]byte(request.Body), &action))
+			found = true
+		}
+	}
+	require.True(t, found)
+	assert.Equal(t, "reinstall", action.Action)
+	assert.Equal(t, 10, action.Params.OSID)
+	assert.Equal(t, "#cloud-config\npackages: [nginx]\n", action.Params.UserData)
+
+	var sizes []interface{}
+	for _, node := range action.Params.PartitionsConfig {
+		node := node.(map[string]interface{})
+		if node["type"] == "partition" {
+			sizes = append(sizes, node["size"])
+		}
+	}
+	assert.Contains(t, sizes, float64(30))
+
+	server, ok = api.Server(serverUUID)
+	require.True(t, ok)
+	assert.Equal(t, "active", server.Status)
+}
+
+func TestDedicatedServerV1ChangeOSReplacesFake(t *testing.T) {
+	api := fakeservers.New()
+	defer api.Close()
+
+	ctx := context.Background()
+	meta := testServersFakeConfig(t, api)
+	d := schema.TestResourceDataRaw(t, resourceDedicatedServerV1().Schema, testDedicatedServerV1ReinstallConfig(false, 5, 20, ""))
+	require.False(t, resourceDedicatedServerV1Create(ctx, d, meta).HasError())
+
+	diff, _, err := testServersFakePlan(ctx, t, resourceDedicatedServerV1(), d, meta, testDedicatedServerV1ReinstallConfig(false, 10, 20, ""))
+	require.NoError(t, err)
+	assert.True(t, diff.RequiresNew())
+	assert.True(t, diff.Attributes["os_id"].RequiresNew)
+
+	diff, _, err = testServersFakePlan(ctx, t, resourceDedicatedServerV1(), d, meta, testDedicatedServerV1ReinstallConfig(false, 5, 40, ""))
+	require.NoError(t, err)
+	assert.True(t, diff.RequiresNew())
+}
+
+func TestDedicatedServerV1NumericID(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceDedicatedServerV1().Schema, testDedicatedServerV1ReinstallConfig(true, 5, 20, ""))
+	d.SetId("00000000-0000-4000-8000-000000001001")
+
+	_, err := dedicatedServerV1NumericID(d)
+	assert.ErrorContains(t, err, "numeric ID of dedicated server")
+
+	err = dedicatedServerV1Reinstall(context.Background(), d, nil, time.Minute)
+	assert.ErrorContains(t, err, "reinstall_on_change")
+}
+
+func TestDedicatedServerV1ReinstallTemplateFake(t *testing.T) {
+	api := fakeservers.New()
+	defer api.Close()
+
+	ctx := context.Background()
+	meta := testServersFakeConfig(t, api)
+	serversService, err := meta.GetServersService()
+	require.NoError(t, err)
+
+	templates := make([]string, 0, 2)
+	for _, name := range []string{"debian-lvm", "debian-raid"} {
+		template, err := serversService.CreateOSTemplate(ctx, &servers.ServerOSTemplateCreate{
+			Name:        name,
+			BasedOn:     "debian",
+			Version:     "12",
+			InstallType: servers.OSTemplateInstallTypePreseed,
+			Content:     testDedicatedOSTemplateV1Content,
+		})
+		require.NoError(t, err)
+		templates = append(templates, template.UUID)
+	}
+
+	raw := testDedicatedServerV1ReinstallConfig(true, 0, 20, "")
+	delete(raw, "os_id")
+	raw["os_template_id"] = templates[0]
+	d := schema.TestResourceDataRaw(t, resourceDedicatedServerV1().Schema, raw)
+	require.False(t, resourceDedicatedServerV1Create(ctx, d, meta).HasError())
+
+	raw["os_template_id"] = templates[1]
+	_, next, err := testServersFakePlan(ctx, t, resourceDedicatedServerV1(), d, meta, raw)
+	require.NoError(t, err)
+
+	diags := resourceDedicatedServerV1Update(ctx, next, meta)
+	require.False(t, diags.HasError(), diags)
+
+	actions := testServersFakeActions(api)
+	require.Len(t, actions, 1)
+
+	var action struct {
+		Params struct {
+			OSID               int    `json:"os_id"`
+			CustomTemplateUUID string `json:"custom_template_uuid"`
+		} `json:"params"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(actions[0]), &action))
+	// ОС, на которой основан шаблон, а не 0
+	assert.Equal(t, fakeservers.OSID, action.Params.OSID)
+	assert.Equal(t, templates[1], action.Params.CustomTemplateUUID)
+}
+
+func TestDedicatedServerV1ChangeSSHKeysFake(t *testing.T) {
+	api := fakeservers.New()
+	defer api.Close()
+
+	ctx := context.Background()
+	meta := testServersFakeConfig(t, api)
+	raw := testDedicatedServerV1ReinstallConfig(false, 5, 20, "")
+	raw["ssh_keys"] = []interface{}{"ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIKey1 ci@example"}
+	d := schema.TestResourceDataRaw(t, resourceDedicatedServerV1().Schema, raw)
+	require.False(t, resourceDedicatedServerV1Create(ctx, d, meta).HasError())
+
+	raw["ssh_keys"] = []interface{}{"ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIKey2 ci@example"}
+	diff, next, err := testServersFakePlan(ctx, t, resourceDedicatedServerV1(), d, meta, raw)
+	require.NoError(t, err)
+	assert.False(t, diff.RequiresNew())
+
+	diags := resourceDedicatedServerV1Update(ctx, next, meta)
+	require.False(t, diags.HasError(), diags)
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.Warning, diags[0].Severity)
+
+	// Ключи запоминаются до следующей установки, сервер не переустановлен
+	assert.Empty(t, testServersFakeActions(api))
+	assert.Equal(t, raw["ssh_keys"], next.Get("ssh_keys"))
+
+	groupSchema := resourceDedicatedServerGroupV1().Schema
+	for _, key := range dedicatedServerV1InstallOnlyKeys {
+		assert.False(t, groupSchema[key].ForceNew, key)
+	}
+}
+
+func TestDedicatedServerV1ChangeSSHKeysReinstallFake(t *testing.T) {
+	api := fakeservers.New()
+	defer api.Close()
+
+	ctx := context.Background()
+	meta := testServersFakeConfig(t, api)
+	raw := testDedicatedServerV1ReinstallConfig(true, 5, 20, "")
+	raw["ssh_keys"] = []interface{}{"ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIKey1 ci@example"}
+	d := schema.TestResourceDataRaw(t, resourceDedicatedServerV1().Schema, raw)
+	require.False(t, resourceDedicatedServerV1Create(ctx, d, meta).HasError())
+
+	raw["ssh_keys"] = []interface{}{"ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIKey2 ci@example"}
+	diff, next, err := testServersFakePlan(ctx, t, resourceDedicatedServerV1(), d, meta, raw)
+	require.NoError(t, err)
+	assert.False(t, diff.RequiresNew())
+
+	diags := resourceDedicatedServerV1Update(ctx, next, meta)
+	require.False(t, diags.HasError(), diags)
+	assert.Empty(t, diags)
+
+	// С reinstall_on_change новые ключи записываются переустановкой
+	actions := testServersFakeActions(api)
+	require.Len(t, actions, 1)
+	assert.Contains(t, actions[0], "reinstall")
+	assert.Contains(t, actions[0], "AAAAIKey2")
+}
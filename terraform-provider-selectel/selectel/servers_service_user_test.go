@@ -0,0 +1,192 @@
+package selectel
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/fakeservers"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/servers"
+)
+
+// testServersKeystone is a Keystone stand-in that issues a token named
+// after the user for one set of credentials and one project scope.
+func testServersKeystone(t *testing.T, username, password, projectID string) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/identity/v3/auth/tokens" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		token := map[string]interface{}{
+			"token": map[string]interface{}{
+				"expires_at": time.Now().Add(24 * time.Hour).UTC().Format(time.RFC3339),
+				"catalog": []interface{}{
+					map[string]interface{}{
+						"type": "identity",
+						"endpoints": []interface{}{
+							map[string]interface{}{
+								"interface": "public",
+								"region":    "ru-9",
+								"region_id": "ru-9",
+								"url":       server.URL + "/identity/v3/",
+							},
+						},
+					},
+				},
+			},
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet {
+			_ = json.NewEncoder(w).Encode(token)
+			return
+		}
+
+		var request struct {
+			Auth struct {
+				Identity struct {
+					Password struct {
+						User struct {
+							Name     string `json:"name"`
+							Password string `json:"password"`
+						} `json:"user"`
+					} `json:"password"`
+				} `json:"identity"`
+				Scope struct {
+					Project struct {
+						ID string `json:"id"`
+					} `json:"project"`
+				} `json:"scope"`
+			} `json:"auth"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		user := request.Auth.Identity.Password.User
+		if user.Name != username || user.Password != password || request.Auth.Scope.Project.ID != projectID {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = fmt.Fprint(w, `{"error": {"code": 401, "message": "The request you have made requires authentication."}}`)
+			return
+		}
+
+		w.Header().Set("X-Subject-Token", "token-"+user.Name)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(token)
+	}))
+
+	return server
+}
+
+func testServersServiceUserConfigure(t *testing.T, keystone *httptest.Server, api *fakeservers.API, raw map[string]interface{}) (*Config, error) {
+	settings := map[string]interface{}{
+		"auth_url":         keystone.URL + "/identity/v3/",
+		"auth_region":      "ru-9",
+		"domain_name":      "000000",
+		"username":         "provider-user",
+		"password":         "provider-password",
+		"servers_endpoint": api.URL,
+	}
+	for key, value := range raw {
+		settings[key] = value
+	}
+
+	return testSharedCredentialsConfigure(t, settings)
+}
+
+func TestServersServiceUserTokenFake(t *testing.T) {
+	testSharedCredentialsEnv(t)
+
+	keystone := testServersKeystone(t, "ci", "ci-password", "p1")
+	defer keystone.Close()
+	api := fakeservers.New()
+	defer api.Close()
+	api.SetToken("token-ci")
+
+	config, err := testServersServiceUserConfigure(t, keystone, api, map[string]interface{}{
+		"servers_username":   "ci",
+		"servers_password":   "ci-password",
+		"servers_project_id": "p1",
+	})
+	require.NoError(t, err)
+
+	serversService, err := config.GetServersService()
+	require.NoError(t, err)
+
+	_, err = serversService.ListLocations(context.Background())
+	require.NoError(t, err)
+
+	// Импорт не требует servers_token
+	d := schema.TestResourceDataRaw(t, resourceDedicatedServerV1().Schema, map[string]interface{}{})
+	d.SetId("1001")
+	imported, err := resourceDedicatedServerV1ImportState(context.Background(), d, config)
+	require.NoError(t, err)
+	assert.Len(t, imported, 1)
+
+	// Токен сервисного пользователя без нужных прав
+	api.AddHook(fakeservers.Hook{Method: http.MethodGet, Path: "location", Status: http.StatusForbidden, Times: 1})
+	_, err = serversService.ListLocations(context.Background())
+	assert.ErrorIs(t, err, servers.ErrForbidden)
+	assert.ErrorContains(t, err, "rejected the token of service user ci (project-scoped token for project p1)")
+}
+
+func TestServersServiceUserScopeErrorFake(t *testing.T) {
+	testSharedCredentialsEnv(t)
+
+	keystone := testServersKeystone(t, "ci", "ci-password", "p1")
+	defer keystone.Close()
+	api := fakeservers.New()
+	defer api.Close()
+
+	config, err := testServersServiceUserConfigure(t, keystone, api, map[string]interface{}{
+		"servers_username":   "ci",
+		"servers_password":   "ci-password",
+		"servers_project_id": "p2",
+	})
+	require.NoError(t, err)
+
+	serversService, err := config.GetServersService()
+	require.NoError(t, err)
+
+	_, err = serversService.ListLocations(context.Background())
+	assert.ErrorContains(t, err, "failed to obtain a token for service user ci (project-scoped token for project p2)")
+	assert.ErrorContains(t, err, "has a role in project p2")
+	assert.Empty(t, api.Requests())
+}
+
+func TestServersServiceUserValidation(t *testing.T) {
+	testSharedCredentialsEnv(t)
+
+	keystone := testServersKeystone(t, "ci", "ci-password", "")
+	defer keystone.Close()
+	api := fakeservers.New()
+	defer api.Close()
+
+	_, err := testServersServiceUserConfigure(t, keystone, api, map[string]interface{}{
+		"servers_username": "ci",
+	})
+	assert.ErrorContains(t, err, "servers_username and servers_password must be set together")
+
+	_, err = testServersServiceUserConfigure(t, keystone, api, map[string]interface{}{
+		"servers_project_id": "p1",
+	})
+	assert.ErrorContains(t, err, "servers_project_id requires servers_username")
+
+	config, err := testServersServiceUserConfigure(t, keystone, api, map[string]interface{}{
+		"servers_username": "ci",
+		"servers_password": "ci-password",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "service user ci (domain-scoped token for account 000000)", config.serversAuthDescription())
+
+	config.ServersToken = "static"
+	assert.Contains(t, config.serversAuthDescription(), "servers_token")
+	assert.NotContains(t, config.serversAuthDescription(), "service user ci")
+}
@@ -0,0 +1,93 @@
+package selectel
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/servers"
+)
+
+// validateServerSSHPublicKey проверяет, что значение является публичным ключом
+// в формате authorized_keys (ssh-rsa, ssh-ed25519, ecdsa-sha2-*).
+func validateServerSSHPublicKey(v interface{}, k string) ([]string, []error) {
+	value, ok := v.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+
+	if _, err := parseServerSSHPublicKey(value); err != nil {
+		return nil, []error{fmt.Errorf("%s is not a valid SSH public key: %s", k, err)}
+	}
+
+	return nil, nil
+}
+
+// parseServerSSHPublicKey разбирает одну строку публичного ключа.
+func parseServerSSHPublicKey(publicKey string) (ssh.PublicKey, error) {
+	publicKey = strings.TrimSpace(publicKey)
+	if strings.Contains(publicKey, "\n") {
+		return nil, fmt.Errorf("expected a single key, got several lines")
+	}
+
+	key, _, options, _, err := ssh.ParseAuthorizedKey([]byte(publicKey))
+	if err != nil {
+		return nil, err
+	}
+	if len(options) > 0 {
+		return nil, fmt.Errorf("authorized_keys options are not supported")
+	}
+
+	return key, nil
+}
+
+// serverSSHKeyFingerprint возвращает SHA256 отпечаток публичного ключа в
+// формате ssh-keygen -l.
+func serverSSHKeyFingerprint(publicKey string) (string, error) {
+	key, err := parseServerSSHPublicKey(publicKey)
+	if err != nil {
+		return "", err
+	}
+
+	return ssh.FingerprintSHA256(key), nil
+}
+
+// normalizeServerSSHPublicKey используется как StateFunc, чтобы завершающий
+// перевод строки из file() не вызывал diff.
+func normalizeServerSSHPublicKey(v interface{}) string {
+	return strings.TrimSpace(v.(string))
+}
+
+// resolveServerSSHKeys находит зарегистрированные ключи по UUID или имени и
+// возвращает их публичные части в том же порядке.
+func resolveServerSSHKeys(ctx context.Context, serversService servers.ServersAPI, refs []string) ([]string, error) {
+	if len(refs) == 0 {
+		return nil, nil
+	}
+
+	keys, err := serversService.ListSSHKeys(ctx)
+	if err != nil {
+		return nil, errGettingObjects("server ssh keys", err)
+	}
+
+	publicKeys := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		key, err := findServerSSHKey(keys, ref)
+		if err != nil {
+			return nil, err
+		}
+		publicKeys = append(publicKeys, key.PublicKey)
+	}
+
+	return publicKeys, nil
+}
+
+// findServerSSHKey ищет ключ по UUID, а затем по имени
+func findServerSSHKey(keys []*servers.ServerSSHKey, ref string) (*servers.ServerSSHKey, error) {
+	return findServerObject(keys, objectServerSSHKey, ref,
+		func(key *servers.ServerSSHKey) string { return key.UUID },
+		func(key *servers.ServerSSHKey) string { return key.Name },
+	)
+}
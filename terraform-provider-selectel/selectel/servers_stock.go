@@ -0,0 +1,99 @@
+package selectel
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/servers"
+)
+
+// Состояния ожидания наличия серверов
+const (
+	serverStockStateOutOfStock = "out_of_stock"
+	serverStockStateInStock    = "in_stock"
+)
+
+// serverStockPollInterval — период повторной проверки наличия при
+// wait_for_stock
+var serverStockPollInterval = time.Minute
+
+// serverStockRequest описывает услугу, конфигурацию и локацию, наличие
+// которых ожидается
+type serverStockRequest struct {
+	ServiceUUID  string
+	ConfigID     int
+	LocationUUID string
+}
+
+func (r serverStockRequest) String() string {
+	if r.ConfigID != 0 {
+		return fmt.Sprintf("service %s (config %d) in location %s", r.ServiceUUID, r.ConfigID, r.LocationUUID)
+	}
+
+	return fmt.Sprintf("service %s in location %s", r.ServiceUUID, r.LocationUUID)
+}
+
+// serverAvailabilityMatchesConfig сообщает, относится ли строка наличия к
+// конфигурации configID. Без configID подходит любая строка
+func serverAvailabilityMatchesConfig(av *servers.ServerAvailability, configID int) bool {
+	return configID == 0 || av.ConfigID == configID
+}
+
+// serverStockStateRefreshFunc возвращает число доступных серверов и
+// состояние in_stock, если хотя бы один сервер можно заказать
+func serverStockStateRefreshFunc(ctx context.Context, serversService servers.ServersAPI, request serverStockRequest) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		availability, err := serversService.ListServerAvailability(ctx, &servers.ServerAvailabilityListOpts{
+			ServiceUUID:  request.ServiceUUID,
+			LocationUUID: request.LocationUUID,
+		})
+		if err != nil {
+			return nil, "", err
+		}
+
+		quantity := 0
+		var restockAt *time.Time
+		for _, av := range availability {
+			if !serverAvailabilityMatchesConfig(av, request.ConfigID) {
+				continue
+			}
+			quantity += av.Quantity
+			if av.RestockAt != nil && (restockAt == nil || av.RestockAt.Before(*restockAt)) {
+				restockAt = av.RestockAt
+			}
+		}
+
+		if quantity > 0 {
+			log.Printf("[INFO] %d servers of %s are in stock", quantity, request)
+			return quantity, serverStockStateInStock, nil
+		}
+
+		if restockAt != nil {
+			log.Printf("[INFO] Waiting for stock of %s, expected restock at %s", request, restockAt.Format(time.RFC3339))
+		} else {
+			log.Printf("[INFO] Waiting for stock of %s, no restock date is known", request)
+		}
+
+		return quantity, serverStockStateOutOfStock, nil
+	}
+}
+
+// waitForServerStock ожидает появления серверов в наличии не дольше timeout
+func waitForServerStock(ctx context.Context, serversService servers.ServersAPI, request serverStockRequest, timeout time.Duration) error {
+	stateConf := &resource.StateChangeConf{
+		Pending:      []string{serverStockStateOutOfStock},
+		Target:       []string{serverStockStateInStock},
+		Refresh:      serverStockStateRefreshFunc(ctx, serversService, request),
+		Timeout:      timeout,
+		PollInterval: serverStockPollInterval,
+	}
+
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("error waiting for stock of %s: %w", request, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,219 @@
+package selectel
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/internal/fakeservers"
+	"github.com/terraform-providers/terraform-provider-selectel/selectel/servers"
+)
+
+func testServerStockPollInterval(t *testing.T) {
+	interval := serverStockPollInterval
+	serverStockPollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { serverStockPollInterval = interval })
+}
+
+func testServerStockResourceData(t *testing.T, waitForStock bool) *schema.ResourceData {
+	return schema.TestResourceDataRaw(t, resourceDedicatedServerV1().Schema, map[string]interface{}{
+		"name":           "capacity-1",
+		"location_id":    1,
+		"root_size":      20,
+		"wait_for_stock": waitForStock,
+	})
+}
+
+func testServerStockOrderRequests(api *fakeservers.API) int {
+	count := 0
+	for _, request := range api.Requests() {
+		if request.Method == http.MethodPost && request.Path == "resource/serverchip/billing" {
+			count++
+		}
+	}
+
+	return count
+}
+
+func TestDedicatedServerV1WaitForStockFake(t *testing.T) {
+	testServerStockPollInterval(t)
+
+	api := fakeservers.New()
+	defer api.Close()
+
+	api.SetAvailability(fakeservers.Availability{
+		ServiceUUID:  fakeservers.ServiceUUID,
+		LocationUUID: fakeservers.LocationUUID,
+	})
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		api.SetAvailability(fakeservers.Availability{
+			ServiceUUID:  fakeservers.ServiceUUID,
+			LocationUUID: fakeservers.LocationUUID,
+			Quantity:     1,
+		})
+	}()
+
+	d := testServerStockResourceData(t, true)
+	diags := resourceDedicatedServerV1Create(context.Background(), d, testServersFakeConfig(t, api))
+	require.False(t, diags.HasError(), diags)
+	assert.NotEmpty(t, d.Id())
+	assert.Len(t, api.Orders(), 1)
+
+	checks := 0
+	for _, request := range api.Requests() {
+		if request.Path == "service/availability" {
+			checks++
+		}
+	}
+	assert.Greater(t, checks, 1)
+}
+
+func TestServerStockStateRefreshFuncConfigFake(t *testing.T) {
+	api := fakeservers.New()
+	defer api.Close()
+
+	api.SetAvailability(fakeservers.Availability{
+		ServiceUUID:  fakeservers.ServiceUUID,
+		ConfigID:     fakeservers.ConfigurationID,
+		LocationUUID: fakeservers.LocationUUID,
+	})
+	api.AddAvailability(fakeservers.Availability{
+		ServiceUUID:  fakeservers.ServiceUUID,
+		LocationUUID: fakeservers.LocationUUID,
+		Quantity:     5,
+	})
+
+	serversService, err := testServersFakeConfig(t, api).GetServersService()
+	require.NoError(t, err)
+
+	refresh := serverStockStateRefreshFunc(context.Background(), serversService, serverStockRequest{
+		ServiceUUID:  fakeservers.ServiceUUID,
+		ConfigID:     fakeservers.ConfigurationID,
+		LocationUUID: fakeservers.LocationUUID,
+	})
+	quantity, state, err := refresh()
+	require.NoError(t, err)
+	assert.Equal(t, serverStockStateOutOfStock, state)
+	assert.Equal(t, 0, quantity)
+}
+
+func TestDedicatedServerV1WaitForStockSoldOutFake(t *testing.T) {
+	testServerStockPollInterval(t)
+
+	api := fakeservers.New()
+	defer api.Close()
+
+	api.AddHook(fakeservers.Hook{
+		Method:    http.MethodPost,
+		Path:      "resource/serverchip/billing",
+		Status:    http.StatusConflict,
+		ErrorCode: servers.ErrorCodeOutOfStock,
+		Times:     1,
+	})
+
+	d := testServerStockResourceData(t, true)
+	diags := resourceDedicatedServerV1Create(context.Background(), d, testServersFakeConfig(t, api))
+	require.False(t, diags.HasError(), diags)
+	assert.Equal(t, 2, testServerStockOrderRequests(api))
+	assert.Len(t, api.Orders(), 1)
+}
+
+func TestDedicatedServerV1OutOfStockOrderTimeoutFake(t *testing.T) {
+	testServerStockPollInterval(t)
+
+	api := fakeservers.New()
+	defer api.Close()
+
+	// Наличие есть, но заказ каждый раз отклоняется
+	api.AddHook(fakeservers.Hook{
+		Method:    http.MethodPost,
+		Path:      "resource/serverchip/billing",
+		Status:    http.StatusConflict,
+		ErrorCode: servers.ErrorCodeOutOfStock,
+	})
+
+	serversService, err := testServersFakeConfig(t, api).GetServersService()
+	require.NoError(t, err)
+
+	stockRequest := serverStockRequest{ServiceUUID: fakeservers.ServiceUUID, LocationUUID: fakeservers.LocationUUID}
+	billingOpts := &servers.DedicatedServerCreateBilling{
+		Name:         "capacity-1",
+		ServiceUUID:  fakeservers.ServiceUUID,
+		LocationUUID: fakeservers.LocationUUID,
+	}
+
+	started := time.Now()
+	_, err = placeDedicatedServerOrder(context.Background(), serversService, billingOpts, stockRequest, true, started.Add(100*time.Millisecond))
+	assert.ErrorContains(t, err, "still out of stock")
+	assert.ErrorIs(t, err, servers.ErrOutOfStock)
+	assert.Less(t, time.Since(started), 5*time.Second)
+
+	// Между заказами выдерживается пауза
+	orders := testServerStockOrderRequests(api)
+	assert.Greater(t, orders, 1)
+	assert.LessOrEqual(t, orders, 11)
+}
+
+func TestDedicatedServerV1OutOfStockWithoutWaitFake(t *testing.T) {
+	api := fakeservers.New()
+	defer api.Close()
+
+	api.AddHook(fakeservers.Hook{
+		Method:    http.MethodPost,
+		Path:      "resource/serverchip/billing",
+		Status:    http.StatusConflict,
+		ErrorCode: servers.ErrorCodeOutOfStock,
+		Times:     1,
+	})
+
+	d := testServerStockResourceData(t, false)
+	diags := resourceDedicatedServerV1Create(context.Background(), d, testServersFakeConfig(t, api))
+	require.True(t, diags.HasError())
+	assert.Equal(t, 1, testServerStockOrderRequests(api))
+	assert.Empty(t, d.Id())
+}
+
+func TestDedicatedServerV1WaitForStockTimeoutFake(t *testing.T) {
+	testServerStockPollInterval(t)
+
+	api := fakeservers.New()
+	defer api.Close()
+
+	api.SetAvailability(fakeservers.Availability{
+		ServiceUUID:  fakeservers.ServiceUUID,
+		LocationUUID: fakeservers.LocationUUID,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	d := testServerStockResourceData(t, true)
+	diags := resourceDedicatedServerV1Create(ctx, d, testServersFakeConfig(t, api))
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "waiting for stock")
+	assert.Equal(t, 0, testServerStockOrderRequests(api))
+}
+
+func TestDedicatedServerV1QuotaExceededFake(t *testing.T) {
+	api := fakeservers.New()
+	defer api.Close()
+
+	api.AddHook(fakeservers.Hook{
+		Method:    http.MethodPost,
+		Path:      "resource/serverchip/billing",
+		Status:    http.StatusForbidden,
+		ErrorCode: servers.ErrorCodeQuotaExceeded,
+	})
+
+	// Превышение квоты не ждет наличия: повтор заказа не поможет
+	d := testServerStockResourceData(t, true)
+	diags := resourceDedicatedServerV1Create(context.Background(), d, testServersFakeConfig(t, api))
+	require.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "exceeds the servers quota of the account")
+	assert.Equal(t, 1, testServerStockOrderRequests(api))
+}
@@ -0,0 +1,119 @@
+package selectel
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// defaultSharedCredentialsFile — файл учетных данных, который читается,
+	// если shared_credentials_file не задан
+	defaultSharedCredentialsFile = "~/.selectel/credentials"
+
+	// defaultSharedCredentialsProfile — профиль, который используется, если
+	// profile не задан
+	defaultSharedCredentialsProfile = "default"
+)
+
+// sharedCredentialsProfile — именованный профиль из файла учетных данных.
+// Файл — YAML, где ключ верхнего уровня — имя профиля:
+//
+//	default:
+//	  auth_url: https://cloud.api.selcloud.ru/identity/v3/
+//	  auth_region: ru-9
+//	  domain_name: "123456"
+//	  username: terraform
+//	  password: secret
+//	staging:
+//	  ...
+type sharedCredentialsProfile struct {
+	AuthURL        string `yaml:"auth_url"`
+	AuthRegion     string `yaml:"auth_region"`
+	DomainName     string `yaml:"domain_name"`
+	UserDomainName string `yaml:"user_domain_name"`
+	Username       string `yaml:"username"`
+	Password       string `yaml:"password"`
+	ProjectID      string `yaml:"project_id"`
+	Region         string `yaml:"region"`
+
+	// Доступ к API выделенных серверов
+	ServersToken     string `yaml:"servers_token"`
+	ServersUsername  string `yaml:"servers_username"`
+	ServersPassword  string `yaml:"servers_password"`
+	ServersProjectID string `yaml:"servers_project_id"`
+}
+
+// loadSharedCredentialsProfile читает профиль из файла учетных данных.
+// Отсутствие файла по умолчанию или профиля default не ошибка: тогда
+// возвращается nil. Если файл или профиль заданы явно, они обязаны быть
+func loadSharedCredentialsProfile(path, profile string) (*sharedCredentialsProfile, error) {
+	explicit := path != "" || profile != ""
+	if path == "" {
+		path = defaultSharedCredentialsFile
+	}
+	if profile == "" {
+		profile = defaultSharedCredentialsProfile
+	}
+
+	path, err := expandHomeDir(path)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) && !explicit {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to read shared credentials file: %w", err)
+	}
+
+	var profiles map[string]sharedCredentialsProfile
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&profiles); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse shared credentials file %s: %w", path, err)
+	}
+
+	credentials, ok := profiles[profile]
+	if !ok {
+		if !explicit {
+			return nil, nil
+		}
+
+		names := make([]string, 0, len(profiles))
+		for name := range profiles {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		return nil, fmt.Errorf("profile %q is not found in shared credentials file %s, available profiles: %s",
+			profile, path, strings.Join(names, ", "))
+	}
+
+	return &credentials, nil
+}
+
+// expandHomeDir раскрывает ~ в начале пути
+func expandHomeDir(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to expand %s: %w", path, err)
+	}
+
+	return filepath.Join(home, strings.TrimPrefix(path, "~")), nil
+}
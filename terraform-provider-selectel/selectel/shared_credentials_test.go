@@ -0,0 +1,146 @@
+package selectel
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSharedCredentials = `
+default:
+  auth_url: https://cloud.api.selcloud.ru/identity/v3/
+  auth_region: ru-9
+  domain_name: "111111"
+  username: default-user
+  password: default-password
+staging:
+  auth_url: https://staging.example.com/identity/v3/
+  auth_region: ru-7
+  domain_name: "222222"
+  username: staging-user
+  password: staging-password
+  servers_token: staging-servers-token
+  project_id: staging-project
+`
+
+// testSharedCredentialsEnv isolates the test from the credentials of the
+// machine it runs on and returns the path of a credentials file.
+func testSharedCredentialsEnv(t *testing.T) string {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	for _, env := range []string{
+		"OS_AUTH_URL", "OS_REGION_NAME", "OS_DOMAIN_NAME", "OS_USERNAME", "OS_PASSWORD", "OS_USER_DOMAIN_NAME",
+		"INFRA_PROJECT_ID", "INFRA_REGION", "SEL_SERVERS_TOKEN", "SEL_SERVERS_USERNAME", "SEL_SERVERS_PASSWORD", "SEL_SERVERS_PROJECT_ID",
+		"SEL_PROFILE", "SEL_SHARED_CREDENTIALS_FILE",
+	} {
+		t.Setenv(env, "")
+	}
+
+	path := filepath.Join(home, "credentials.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(testSharedCredentials), 0o600))
+
+	return path
+}
+
+func testSharedCredentialsConfigure(t *testing.T, raw map[string]interface{}) (*Config, error) {
+	provider := Provider()
+	diags := provider.Configure(context.Background(), terraform.NewResourceConfigRaw(raw))
+	if diags.HasError() {
+		return nil, errors.New(diags[0].Summary)
+	}
+
+	return provider.Meta().(*Config), nil
+}
+
+func TestLoadSharedCredentialsProfile(t *testing.T) {
+	path := testSharedCredentialsEnv(t)
+
+	profile, err := loadSharedCredentialsProfile(path, "staging")
+	require.NoError(t, err)
+	assert.Equal(t, "staging-user", profile.Username)
+	assert.Equal(t, "staging-servers-token", profile.ServersToken)
+
+	profile, err = loadSharedCredentialsProfile(path, "")
+	require.NoError(t, err)
+	assert.Equal(t, "default-user", profile.Username)
+
+	// Файла по умолчанию нет, и это не ошибка
+	profile, err = loadSharedCredentialsProfile("", "")
+	require.NoError(t, err)
+	assert.Nil(t, profile)
+
+	_, err = loadSharedCredentialsProfile("", "staging")
+	assert.ErrorContains(t, err, "failed to read shared credentials file")
+
+	_, err = loadSharedCredentialsProfile(path, "production")
+	assert.ErrorContains(t, err, `profile "production" is not found`)
+	assert.ErrorContains(t, err, "default, staging")
+
+	invalid := filepath.Join(t.TempDir(), "credentials")
+	require.NoError(t, os.WriteFile(invalid, []byte("default:\n  user_name: typo\n"), 0o600))
+	_, err = loadSharedCredentialsProfile(invalid, "")
+	assert.ErrorContains(t, err, "user_name")
+}
+
+func TestLoadSharedCredentialsProfileHomeDir(t *testing.T) {
+	testSharedCredentialsEnv(t)
+
+	home, err := os.UserHomeDir()
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(home, ".selectel"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(home, ".selectel", "credentials"), []byte(testSharedCredentials), 0o600))
+
+	profile, err := loadSharedCredentialsProfile("", "staging")
+	require.NoError(t, err)
+	assert.Equal(t, "staging-user", profile.Username)
+}
+
+func TestProviderSharedCredentialsPrecedence(t *testing.T) {
+	path := testSharedCredentialsEnv(t)
+
+	config, err := testSharedCredentialsConfigure(t, map[string]interface{}{
+		"shared_credentials_file": path,
+		"profile":                 "staging",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "https://staging.example.com/identity/v3/", config.AuthURL)
+	assert.Equal(t, "222222", config.DomainName)
+	assert.Equal(t, "staging-user", config.Username)
+	assert.Equal(t, "staging-servers-token", config.ServersToken)
+	assert.Equal(t, "staging-project", config.ProjectID)
+
+	// Переменные окружения важнее профиля, аргументы провайдера важнее всего
+	t.Setenv("SEL_PROFILE", "staging")
+	t.Setenv("SEL_SHARED_CREDENTIALS_FILE", path)
+	t.Setenv("OS_USERNAME", "env-user")
+	t.Setenv("OS_PASSWORD", "env-password")
+
+	config, err = testSharedCredentialsConfigure(t, map[string]interface{}{
+		"username": "explicit-user",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "explicit-user", config.Username)
+	assert.Equal(t, "env-password", config.Password)
+	assert.Equal(t, "staging-servers-token", config.ServersToken)
+}
+
+func TestProviderSharedCredentialsMissing(t *testing.T) {
+	path := testSharedCredentialsEnv(t)
+
+	_, err := testSharedCredentialsConfigure(t, map[string]interface{}{
+		"username": "explicit-user",
+	})
+	assert.ErrorContains(t, err, "auth_region, auth_url, domain_name, password")
+
+	_, err = testSharedCredentialsConfigure(t, map[string]interface{}{
+		"shared_credentials_file": path,
+		"profile":                 "production",
+	})
+	assert.ErrorContains(t, err, `profile "production" is not found`)
+}
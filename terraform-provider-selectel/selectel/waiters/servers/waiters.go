@@ -2,12 +2,13 @@ package servers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
-	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	serversapi "github.com/terraform-providers/terraform-provider-selectel/selectel/servers"
 )
 
 // WaiterConfig содержит настройки для ожидания состояний
@@ -219,23 +220,11 @@ func WaitForServerToBeInStates(ctx context.Context, serversService ServerService
 // Вспомогательные функции для определения типа ошибки
 
 func isServerNotFoundError(err error) bool {
-	if err == nil {
-		return false
-	}
-	errorStr := strings.ToLower(err.Error())
-	return strings.Contains(errorStr, "not found") ||
-		strings.Contains(errorStr, "404") ||
-		strings.Contains(errorStr, "server not found")
+	return errors.Is(err, serversapi.ErrNotFound)
 }
 
 func isTaskNotFoundError(err error) bool {
-	if err == nil {
-		return false
-	}
-	errorStr := strings.ToLower(err.Error())
-	return strings.Contains(errorStr, "not found") ||
-		strings.Contains(errorStr, "404") ||
-		strings.Contains(errorStr, "task not found")
+	return errors.Is(err, serversapi.ErrNotFound)
 }
 
 // ServerService интерфейс для работы с серверами (для тестирования)